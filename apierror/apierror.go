@@ -0,0 +1,233 @@
+// Package apierror defines the error responses of the API: machine-readable codes,
+// their HTTP status and messages in Indonesian and English.
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Code is a machine-readable error code
+type Code string
+
+// Error codes
+const (
+	CodeInvalidParam        Code = "INVALID_PARAM"
+	CodeNotFound            Code = "NOT_FOUND"
+	CodeForbidden           Code = "FORBIDDEN"
+	CodeUpstreamUnavailable Code = "UPSTREAM_UNAVAILABLE"
+	CodeUpstreamChanged     Code = "UPSTREAM_CHANGED"
+	CodeRateLimited         Code = "RATE_LIMITED"
+	CodeTimeout             Code = "TIMEOUT"
+	CodeInternal            Code = "INTERNAL"
+)
+
+// StatusClientClosedRequest is used when the client disconnected before the response
+// (kode non-standar yang juga dipakai nginx)
+const StatusClientClosedRequest = 499
+
+// Supported languages of Message
+const (
+	LangEN = "en"
+	LangID = "id"
+)
+
+// Message is a text in every supported language
+type Message struct {
+	EN string
+	ID string
+}
+
+// In returns the text in lang, falling back to English
+func (m Message) In(lang string) string {
+	if lang == LangID && m.ID != "" {
+		return m.ID
+	}
+	return m.EN
+}
+
+// IsZero reports whether the message has no text
+func (m Message) IsZero() bool {
+	return m.EN == "" && m.ID == ""
+}
+
+// definition is the default status and messages of a code
+type definition struct {
+	status int
+	title  Message
+	detail Message
+}
+
+var definitions = map[Code]definition{
+	CodeInvalidParam: {
+		status: http.StatusBadRequest,
+		title:  Message{EN: "Invalid parameter", ID: "Parameter tidak valid"},
+		detail: Message{EN: "The request contains an invalid parameter", ID: "Request berisi parameter yang tidak valid"},
+	},
+	CodeNotFound: {
+		status: http.StatusNotFound,
+		title:  Message{EN: "Not found", ID: "Tidak ditemukan"},
+		detail: Message{EN: "The requested page does not exist on the source site", ID: "Halaman yang diminta tidak ada di situs sumber"},
+	},
+	CodeForbidden: {
+		status: http.StatusForbidden,
+		title:  Message{EN: "Forbidden", ID: "Akses ditolak"},
+		detail: Message{EN: "The request is not allowed", ID: "Request tidak diizinkan"},
+	},
+	CodeUpstreamUnavailable: {
+		status: http.StatusBadGateway,
+		title:  Message{EN: "Source site unavailable", ID: "Situs sumber tidak dapat diakses"},
+		detail: Message{EN: "The source site could not be reached, please try again later", ID: "Situs sumber tidak dapat dihubungi, coba lagi nanti"},
+	},
+	CodeUpstreamChanged: {
+		status: http.StatusBadGateway,
+		title:  Message{EN: "Source site changed", ID: "Situs sumber berubah"},
+		detail: Message{EN: "The source page could not be parsed, its layout may have changed", ID: "Halaman sumber tidak dapat dibaca, strukturnya mungkin berubah"},
+	},
+	CodeRateLimited: {
+		status: http.StatusTooManyRequests,
+		title:  Message{EN: "Rate limited", ID: "Terlalu banyak request"},
+		detail: Message{EN: "The source site is limiting requests, please try again later", ID: "Situs sumber membatasi request, coba lagi nanti"},
+	},
+	CodeTimeout: {
+		status: http.StatusGatewayTimeout,
+		title:  Message{EN: "Request timed out", ID: "Waktu request habis"},
+		detail: Message{EN: "The source site did not respond in time", ID: "Situs sumber tidak merespons tepat waktu"},
+	},
+	CodeInternal: {
+		status: http.StatusInternalServerError,
+		title:  Message{EN: "Internal error", ID: "Kesalahan internal"},
+		detail: Message{EN: "An unexpected error occurred", ID: "Terjadi kesalahan yang tidak terduga"},
+	},
+}
+
+// Error is an API error. Err (penyebab asli) hanya di-log dan tidak pernah dikirim ke client.
+type Error struct {
+	Code   Code
+	Status int
+	// Param is the name of the invalid parameter, if any
+	Param string
+	// Message overrides the default detail message of the code
+	Message Message
+	Err     error
+}
+
+// New creates an error with the default status of code
+func New(code Code, message Message, cause error) *Error {
+	return &Error{Code: code, Status: definitionOf(code).status, Message: message, Err: cause}
+}
+
+// InvalidParam creates an INVALID_PARAM error for the named parameter
+func InvalidParam(param string, message Message, cause error) *Error {
+	e := New(CodeInvalidParam, message, cause)
+	e.Param = param
+	return e
+}
+
+// From returns err as *Error; other errors become INTERNAL
+func From(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return New(CodeInternal, Message{}, err)
+}
+
+// WithStatus returns a copy of e with another HTTP status
+func (e *Error) WithStatus(status int) *Error {
+	copied := *e
+	copied.Status = status
+	return &copied
+}
+
+func (e *Error) Error() string {
+	msg := string(e.Code)
+	if e.Param != "" {
+		msg += " (" + e.Param + ")"
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Title returns the short error title in lang
+func (e *Error) Title(lang string) string {
+	if e.Code == CodeInvalidParam && e.Param != "" {
+		if lang == LangID {
+			return fmt.Sprintf("Parameter %s tidak valid", e.Param)
+		}
+		return fmt.Sprintf("Invalid %s parameter", e.Param)
+	}
+	return definitionOf(e.Code).title.In(lang)
+}
+
+// Detail returns the error message in lang
+func (e *Error) Detail(lang string) string {
+	if !e.Message.IsZero() {
+		return e.Message.In(lang)
+	}
+	return definitionOf(e.Code).detail.In(lang)
+}
+
+// Response is the error body of the /api/v1 routes
+type Response struct {
+	Error     string `json:"error"`
+	Message   string `json:"message"`
+	Code      Code   `json:"code"`
+	RequestID string `json:"request_id"`
+}
+
+// Response builds the /api/v1 error body in lang
+func (e *Error) Response(lang, requestID string) Response {
+	return Response{Error: e.Title(lang), Message: e.Detail(lang), Code: e.Code, RequestID: requestID}
+}
+
+// Language picks the response language from an Accept-Language header.
+// Bahasa dengan q tertinggi yang didukung dipakai; default bahasa Inggris.
+func Language(acceptLanguage string) string {
+	type candidate struct {
+		lang string
+		q    float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if lang != LangID && lang != LangEN {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{lang, q})
+		}
+	}
+	if len(candidates) == 0 {
+		return LangEN
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].lang
+}
+
+// definitionOf returns the definition of code, INTERNAL for unknown codes
+func definitionOf(code Code) definition {
+	if def, ok := definitions[code]; ok {
+		return def
+	}
+	return definitions[CodeInternal]
+}
@@ -0,0 +1,70 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", LangEN},
+		{"id", LangID},
+		{"id-ID,id;q=0.9,en-US;q=0.8,en;q=0.7", LangID},
+		{"en-US,en;q=0.9,id;q=0.8", LangEN},
+		{"fr-FR,id;q=0.5", LangID},
+		{"ja,ko", LangEN},
+		{"en;q=0.2,id;q=0.8", LangID},
+		{"id;q=0", LangEN},
+		{"id;q=abc,en;q=0.1", LangEN},
+	}
+	for _, tt := range tests {
+		if got := Language(tt.header); got != tt.want {
+			t.Errorf("Language(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestErrorResponse(t *testing.T) {
+	e := InvalidParam("page", Message{EN: "Page must be a positive integer", ID: "Page harus bilangan bulat positif"}, nil)
+	if e.Status != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", e.Status)
+	}
+
+	en := e.Response(LangEN, "abc")
+	if en.Error != "Invalid page parameter" || en.Message != "Page must be a positive integer" || en.Code != CodeInvalidParam || en.RequestID != "abc" {
+		t.Errorf("en response = %+v", en)
+	}
+	id := e.Response(LangID, "abc")
+	if id.Error != "Parameter page tidak valid" || id.Message != "Page harus bilangan bulat positif" {
+		t.Errorf("id response = %+v", id)
+	}
+
+	// Pesan penyebab asli tidak boleh bocor ke response
+	cause := errors.New("gagal mengunjungi https://dramaqu.ad/: connection refused")
+	upstream := New(CodeUpstreamUnavailable, Message{}, cause).Response(LangID, "abc")
+	if upstream.Error != "Situs sumber tidak dapat diakses" || upstream.Message == cause.Error() {
+		t.Errorf("upstream response = %+v", upstream)
+	}
+}
+
+func TestFrom(t *testing.T) {
+	wrapped := fmt.Errorf("handler: %w", New(CodeRateLimited, Message{}, nil))
+	if got := From(wrapped); got.Code != CodeRateLimited || got.Status != http.StatusTooManyRequests {
+		t.Errorf("From(wrapped) = %+v", got)
+	}
+
+	cause := errors.New("boom")
+	got := From(cause)
+	if got.Code != CodeInternal || got.Status != http.StatusInternalServerError || !errors.Is(got, cause) {
+		t.Errorf("From(plain) = %+v", got)
+	}
+
+	if changed := got.WithStatus(StatusClientClosedRequest); changed.Status != StatusClientClosedRequest || got.Status != http.StatusInternalServerError {
+		t.Errorf("WithStatus changed the original error")
+	}
+}
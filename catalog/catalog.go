@@ -0,0 +1,116 @@
+// Package catalog menyimpan data drama yang pernah di-scrape oleh service di memori,
+// sehingga fitur seperti saran pencarian dapat dilayani tanpa scraping ulang.
+package catalog
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nabilulilalbab/dramaqu/scrape"
+)
+
+// Drama represents a drama known to the catalog
+type Drama struct {
+	Slug        string
+	Title       string
+	URL         string
+	Cover       string
+	Variants    []string
+	FirstSeen   time.Time
+	LastScraped time.Time
+}
+
+// Catalog is a concurrency-safe in-memory store of scraped dramas
+type Catalog struct {
+	mu       sync.RWMutex
+	dramas   map[string]*Drama
+	episodes map[string]*Episode
+	servers  map[string]*Server
+	index    *prefixIndex
+}
+
+// New creates an empty Catalog
+func New() *Catalog {
+	return &Catalog{
+		dramas:   make(map[string]*Drama),
+		episodes: make(map[string]*Episode),
+		servers:  make(map[string]*Server),
+	}
+}
+
+// UpsertDrama adds a drama to the catalog or merges it with the existing entry.
+// Field kosong tidak menimpa data yang sudah ada, dan judul mentah disimpan sebagai varian.
+func (c *Catalog) UpsertDrama(d Drama) {
+	slug := strings.TrimSpace(d.Slug)
+	if slug == "" || slug == "." || slug == "/" {
+		return
+	}
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	existing, ok := c.dramas[slug]
+	if !ok {
+		existing = &Drama{Slug: slug, FirstSeen: now}
+		c.dramas[slug] = existing
+	}
+
+	if title := scrape.CleanTitle(d.Title); title != "" {
+		existing.Title = title
+	}
+	if d.URL != "" {
+		existing.URL = d.URL
+	}
+	if d.Cover != "" {
+		existing.Cover = d.Cover
+	}
+	existing.Variants = mergeVariants(existing.Variants, append([]string{d.Title}, d.Variants...)...)
+	existing.LastScraped = now
+
+	// Index dibangun ulang secara lazy pada query berikutnya
+	c.index = nil
+}
+
+// Drama returns a copy of the drama stored under slug
+func (c *Catalog) Drama(slug string) (Drama, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	d, ok := c.dramas[slug]
+	if !ok {
+		return Drama{}, false
+	}
+	copied := *d
+	copied.Variants = append([]string(nil), d.Variants...)
+	return copied, true
+}
+
+// Len returns the number of dramas in the catalog
+func (c *Catalog) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.dramas)
+}
+
+// mergeVariants appends new non-empty variants that are not yet present
+func mergeVariants(variants []string, candidates ...string) []string {
+	for _, candidate := range candidates {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "" {
+			continue
+		}
+		duplicate := false
+		for _, v := range variants {
+			if strings.EqualFold(v, candidate) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			variants = append(variants, candidate)
+		}
+	}
+	return variants
+}
@@ -0,0 +1,65 @@
+package catalog
+
+import (
+	"strings"
+	"time"
+)
+
+// Episode represents an episode page known to the catalog
+type Episode struct {
+	URL         string
+	FirstSeen   time.Time
+	PublishedAt time.Time
+}
+
+// ReleasedAt returns the best known release time of the episode.
+// Tanggal dari metadata halaman lebih diutamakan daripada waktu pertama kali terlihat.
+func (e Episode) ReleasedAt() time.Time {
+	if !e.PublishedAt.IsZero() {
+		return e.PublishedAt
+	}
+	return e.FirstSeen
+}
+
+// RecordEpisode registers an episode URL and returns its stored state.
+// publishedAt boleh kosong (zero) jika halaman tidak menyebutkan tanggal.
+func (c *Catalog) RecordEpisode(episodeURL string, publishedAt time.Time) Episode {
+	key := episodeKey(episodeURL)
+	if key == "" {
+		return Episode{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	existing, ok := c.episodes[key]
+	if !ok {
+		existing = &Episode{URL: episodeURL, FirstSeen: time.Now()}
+		c.episodes[key] = existing
+	}
+	if !publishedAt.IsZero() {
+		existing.PublishedAt = publishedAt
+	}
+	return *existing
+}
+
+// Episode returns the stored state of an episode URL
+func (c *Catalog) Episode(episodeURL string) (Episode, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.episodes[episodeKey(episodeURL)]
+	if !ok {
+		return Episode{}, false
+	}
+	return *e, true
+}
+
+// episodeKey normalizes an episode URL so "/slug/2" and "/slug/2/" share one entry
+func episodeKey(episodeURL string) string {
+	key := strings.TrimSpace(episodeURL)
+	if key == "" {
+		return ""
+	}
+	return strings.TrimSuffix(key, "/") + "/"
+}
@@ -0,0 +1,117 @@
+package catalog
+
+import (
+	"sort"
+	"time"
+)
+
+// Server health statuses
+const (
+	HealthUnknown = "unknown"
+	HealthAlive   = "alive"
+	HealthDead    = "dead"
+)
+
+// Server represents a streaming server (embed URL) known to the catalog
+type Server struct {
+	URL         string
+	ServerName  string
+	LastSeen    time.Time
+	Status      string
+	StatusCode  int
+	Latency     time.Duration
+	LastChecked time.Time
+	Error       string
+}
+
+// HealthRank orders statuses for sorting: alive first, dead last
+func HealthRank(status string) int {
+	switch status {
+	case HealthAlive:
+		return 0
+	case HealthDead:
+		return 2
+	}
+	return 1
+}
+
+// TrackServer registers a streaming server URL seen on an episode page.
+// Hasil health check sebelumnya tetap disimpan.
+func (c *Catalog) TrackServer(serverURL, serverName string) Server {
+	if serverURL == "" {
+		return Server{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	existing, ok := c.servers[serverURL]
+	if !ok {
+		existing = &Server{URL: serverURL, Status: HealthUnknown}
+		c.servers[serverURL] = existing
+	}
+	if serverName != "" {
+		existing.ServerName = serverName
+	}
+	existing.LastSeen = time.Now()
+	return *existing
+}
+
+// RecordServerHealth stores the result of a health check
+func (c *Catalog) RecordServerHealth(serverURL string, status string, statusCode int, latency time.Duration, checkErr string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	existing, ok := c.servers[serverURL]
+	if !ok {
+		existing = &Server{URL: serverURL, LastSeen: time.Now()}
+		c.servers[serverURL] = existing
+	}
+	existing.Status = status
+	existing.StatusCode = statusCode
+	existing.Latency = latency
+	existing.LastChecked = time.Now()
+	existing.Error = checkErr
+}
+
+// Server returns the stored state of a streaming server URL
+func (c *Catalog) Server(serverURL string) (Server, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	s, ok := c.servers[serverURL]
+	if !ok {
+		return Server{}, false
+	}
+	return *s, true
+}
+
+// Servers returns every known streaming server, least recently checked first
+func (c *Catalog) Servers() []Server {
+	c.mu.RLock()
+	servers := make([]Server, 0, len(c.servers))
+	for _, s := range c.servers {
+		servers = append(servers, *s)
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].LastChecked.Before(servers[j].LastChecked)
+	})
+	return servers
+}
+
+// PruneServers removes servers not seen on any episode page since before
+func (c *Catalog) PruneServers(before time.Time) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for key, s := range c.servers {
+		if s.LastSeen.Before(before) {
+			delete(c.servers, key)
+			removed++
+		}
+	}
+	return removed
+}
@@ -0,0 +1,187 @@
+package catalog
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/nabilulilalbab/dramaqu/scrape"
+)
+
+// Suggestion represents a title match returned by Suggest
+type Suggestion struct {
+	Slug  string
+	Title string
+	URL   string
+	Cover string
+}
+
+// indexEntry maps a searchable key to a drama slug.
+// Key adalah potongan judul yang dimulai dari awal sebuah kata.
+type indexEntry struct {
+	key     string
+	slug    string
+	atStart bool
+}
+
+// prefixIndex is a sorted list of keys searched with binary search
+type prefixIndex struct {
+	entries []indexEntry
+}
+
+// romanizationFolds menyamakan variasi romanisasi Korea yang umum,
+// contoh: "Eomma" dan "Omma", "Geu" dan "Gu", "Oppa" dan "Opa".
+var romanizationFolds = strings.NewReplacer(
+	"eo", "o",
+	"eu", "u",
+	"oo", "u",
+	"ee", "i",
+	"kk", "k",
+	"tt", "t",
+	"pp", "p",
+	"ss", "s",
+	"jj", "j",
+)
+
+// Suggest returns up to limit dramas whose title variants match the query prefix
+func (c *Catalog) Suggest(query string, limit int) []Suggestion {
+	normalized := normalizeTitle(query)
+	if normalized == "" || limit <= 0 {
+		return []Suggestion{}
+	}
+
+	c.mu.Lock()
+	if c.index == nil {
+		c.index = c.buildIndex()
+	}
+	index := c.index
+	c.mu.Unlock()
+
+	scores := make(map[string]int)
+	for _, q := range uniqueStrings(normalized, foldRomanization(normalized)) {
+		index.scan(q, func(e indexEntry) {
+			score := 2
+			if e.atStart {
+				score = 4
+			}
+			if e.key == q {
+				score++
+			}
+			if score > scores[e.slug] {
+				scores[e.slug] = score
+			}
+		})
+	}
+
+	c.mu.RLock()
+	suggestions := make([]Suggestion, 0, len(scores))
+	for slug := range scores {
+		if d, ok := c.dramas[slug]; ok {
+			suggestions = append(suggestions, Suggestion{Slug: d.Slug, Title: d.Title, URL: d.URL, Cover: d.Cover})
+		}
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if scores[a.Slug] != scores[b.Slug] {
+			return scores[a.Slug] > scores[b.Slug]
+		}
+		if len(a.Title) != len(b.Title) {
+			return len(a.Title) < len(b.Title)
+		}
+		return a.Title < b.Title
+	})
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// buildIndex builds the prefix index from all dramas. Caller must hold c.mu.
+func (c *Catalog) buildIndex() *prefixIndex {
+	index := &prefixIndex{}
+	for slug, d := range c.dramas {
+		variants := append([]string{d.Title, titleFromSlug(slug)}, d.Variants...)
+		seen := make(map[string]bool)
+		for _, variant := range variants {
+			normalized := normalizeTitle(scrape.CleanTitle(variant))
+			for _, v := range uniqueStrings(normalized, foldRomanization(normalized)) {
+				if v == "" || seen[v] {
+					continue
+				}
+				seen[v] = true
+				index.addVariant(slug, v)
+			}
+		}
+	}
+	sort.Slice(index.entries, func(i, j int) bool {
+		return index.entries[i].key < index.entries[j].key
+	})
+	return index
+}
+
+// addVariant indexes every word-start suffix of a normalized variant
+func (p *prefixIndex) addVariant(slug, variant string) {
+	p.entries = append(p.entries, indexEntry{key: variant, slug: slug, atStart: true})
+	for i := 0; i < len(variant); i++ {
+		if variant[i] == ' ' && i+1 < len(variant) {
+			p.entries = append(p.entries, indexEntry{key: variant[i+1:], slug: slug})
+		}
+	}
+}
+
+// scan calls fn for every entry whose key starts with prefix
+func (p *prefixIndex) scan(prefix string, fn func(indexEntry)) {
+	start := sort.Search(len(p.entries), func(i int) bool {
+		return p.entries[i].key >= prefix
+	})
+	for i := start; i < len(p.entries) && strings.HasPrefix(p.entries[i].key, prefix); i++ {
+		fn(p.entries[i])
+	}
+}
+
+// normalizeTitle lowercases a title and collapses punctuation into single spaces
+func normalizeTitle(title string) string {
+	mapped := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, title)
+	return strings.Join(strings.Fields(mapped), " ")
+}
+
+// foldRomanization reduces common Korean romanization variants to one spelling
+func foldRomanization(normalized string) string {
+	return romanizationFolds.Replace(normalized)
+}
+
+// titleFromSlug turns "nonton-love-take-two-subtitle-indonesia" into "love take two"
+func titleFromSlug(slug string) string {
+	title := strings.TrimPrefix(slug, "nonton-")
+	title = strings.TrimSuffix(title, "-subtitle-indonesia")
+	return strings.ReplaceAll(title, "-", " ")
+}
+
+// uniqueStrings returns the non-empty values without duplicates, keeping order
+func uniqueStrings(values ...string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		duplicate := false
+		for _, r := range result {
+			if r == v {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			result = append(result, v)
+		}
+	}
+	return result
+}
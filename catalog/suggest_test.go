@@ -0,0 +1,63 @@
+package catalog
+
+import "testing"
+
+func TestSuggestMatchesTitleVariants(t *testing.T) {
+	c := New()
+	c.UpsertDrama(Drama{
+		Slug:  "nonton-love-take-two-subtitle-indonesia",
+		Title: "Nonton Love Take Two Subtitle Indonesia",
+		URL:   "https://dramaqu.ad/nonton-love-take-two-subtitle-indonesia/",
+		Cover: "https://dramaqu.ad/cover-love.jpg",
+	})
+	c.UpsertDrama(Drama{
+		Slug:     "nonton-mother-subtitle-indonesia",
+		Title:    "Mother",
+		Variants: []string{"Eomma"},
+	})
+	c.UpsertDrama(Drama{Slug: "nonton-lovely-runner-subtitle-indonesia", Title: "Lovely Runner"})
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"title prefix", "love t", "nonton-love-take-two-subtitle-indonesia"},
+		{"word inside title", "take", "nonton-love-take-two-subtitle-indonesia"},
+		{"case and punctuation", "LOVELY-run", "nonton-lovely-runner-subtitle-indonesia"},
+		{"romanized variant", "eom", "nonton-mother-subtitle-indonesia"},
+		{"alternative romanization", "omma", "nonton-mother-subtitle-indonesia"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := c.Suggest(tt.query, 5)
+			if len(got) == 0 || got[0].Slug != tt.want {
+				t.Fatalf("Suggest(%q) = %+v, want first slug %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestRanksTitleStartFirst(t *testing.T) {
+	c := New()
+	c.UpsertDrama(Drama{Slug: "first-love", Title: "First Love"})
+	c.UpsertDrama(Drama{Slug: "love-alarm", Title: "Love Alarm"})
+
+	got := c.Suggest("love", 5)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 suggestions, got %d", len(got))
+	}
+	if got[0].Slug != "love-alarm" {
+		t.Errorf("expected title-start match first, got %q", got[0].Slug)
+	}
+}
+
+func TestSuggestDoesNotMatchSubtitleBoilerplate(t *testing.T) {
+	c := New()
+	c.UpsertDrama(Drama{Slug: "nonton-mother-subtitle-indonesia", Title: "Nonton Mother Subtitle Indonesia"})
+
+	if got := c.Suggest("subtitle", 5); len(got) != 0 {
+		t.Errorf("expected no suggestions for boilerplate words, got %+v", got)
+	}
+}
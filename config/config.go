@@ -1,8 +1,11 @@
 package config
 
 import (
+	"log"
+	"net/url"
 	"os"
 	"strings"
+	"time"
 )
 
 type Config struct {
@@ -11,6 +14,50 @@ type Config struct {
 	Environment string
 	SwaggerHost string
 	IsDynamic   bool
+
+	// BaseURL is the upstream site episode URLs are built from
+	BaseURL string
+	// AllowedHosts are the upstream hostnames user-supplied URLs may point to
+	AllowedHosts []string
+	// PublicURL is the public root of this API, dipakai untuk link self di feed (kosong: tanpa link self)
+	PublicURL string
+
+	// StreamSecret is the HMAC key for signed stream links
+	StreamSecret string
+	// StreamLinkTTL is how long a signed stream link stays valid
+	StreamLinkTTL time.Duration
+	// StreamBindIP binds signed stream links to the requesting client IP
+	StreamBindIP bool
+	// TrustedProxies are the proxy IPs/CIDRs whose X-Forwarded-For is used for the client IP
+	TrustedProxies []string
+
+	// RequestTimeout is the default and maximum deadline of a scraping request (0 disables it)
+	RequestTimeout time.Duration
+
+	// HealthCheckInterval is how often streaming servers are probed (0 disables it)
+	HealthCheckInterval time.Duration
+
+	// Cache is the HTTP cache policy of each endpoint group
+	Cache CacheConfig
+}
+
+// CachePolicy is the Cache-Control of one endpoint group (max-age 0 disables caching)
+type CachePolicy struct {
+	MaxAge               time.Duration
+	StaleWhileRevalidate time.Duration
+}
+
+// CacheConfig holds the cache policy per endpoint group
+type CacheConfig struct {
+	Home     CachePolicy
+	Lists    CachePolicy
+	Schedule CachePolicy
+	Search   CachePolicy
+	Genres   CachePolicy
+	Detail   CachePolicy
+	Episode  CachePolicy
+	Feeds    CachePolicy
+	Upcoming CachePolicy
 }
 
 func LoadConfig() *Config {
@@ -19,6 +66,40 @@ func LoadConfig() *Config {
 		Host:        getEnv("HOST", "localhost"),
 		Environment: getEnv("GIN_MODE", "debug"),
 		IsDynamic:   true, // Always use dynamic host detection
+
+		BaseURL:   strings.TrimSuffix(getEnv("BASE_URL", "https://dramaqu.ad"), "/"),
+		PublicURL: strings.TrimSuffix(getEnv("PUBLIC_URL", ""), "/"),
+
+		StreamSecret:  getEnv("STREAM_SECRET", ""),
+		StreamLinkTTL: getDurationEnv("STREAM_LINK_TTL", 6*time.Hour),
+		StreamBindIP:  getEnv("STREAM_BIND_IP", "false") == "true",
+
+		RequestTimeout: getDurationEnv("REQUEST_TIMEOUT", 60*time.Second),
+
+		HealthCheckInterval: getDurationEnv("HEALTH_CHECK_INTERVAL", 10*time.Minute),
+
+		// Format: "max-age,stale-while-revalidate", contoh CACHE_HOME=1m,5m
+		Cache: CacheConfig{
+			Home:     getCachePolicyEnv("CACHE_HOME", CachePolicy{time.Minute, 5 * time.Minute}),
+			Lists:    getCachePolicyEnv("CACHE_LISTS", CachePolicy{5 * time.Minute, 30 * time.Minute}),
+			Schedule: getCachePolicyEnv("CACHE_SCHEDULE", CachePolicy{30 * time.Minute, 6 * time.Hour}),
+			Search:   getCachePolicyEnv("CACHE_SEARCH", CachePolicy{5 * time.Minute, 30 * time.Minute}),
+			Genres:   getCachePolicyEnv("CACHE_GENRES", CachePolicy{time.Hour, 24 * time.Hour}),
+			Detail:   getCachePolicyEnv("CACHE_DETAIL", CachePolicy{10 * time.Minute, time.Hour}),
+			Episode:  getCachePolicyEnv("CACHE_EPISODE", CachePolicy{5 * time.Minute, 30 * time.Minute}),
+			Feeds:    getCachePolicyEnv("CACHE_FEEDS", CachePolicy{10 * time.Minute, time.Hour}),
+			// Countdown cepat basi, jadi cache-nya jauh lebih pendek dari jadwal
+			Upcoming: getCachePolicyEnv("CACHE_UPCOMING", CachePolicy{time.Minute, 5 * time.Minute}),
+		},
+	}
+
+	config.AllowedHosts = getListEnv("ALLOWED_HOSTS", defaultAllowedHosts(config.BaseURL))
+	// Default tidak ada proxy yang dipercaya: IP client diambil dari koneksi langsung
+	config.TrustedProxies = getListEnv("TRUSTED_PROXIES", nil)
+
+	// Link tanpa masa berlaku tidak masuk akal, kembali ke default
+	if config.StreamLinkTTL == 0 {
+		config.StreamLinkTTL = 6 * time.Hour
 	}
 
 	// For development, use localhost with port
@@ -39,3 +120,58 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value := getEnv(key, "")
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		log.Printf("Nilai %s tidak valid (%q), memakai default %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+// getCachePolicyEnv parses "max-age,stale-while-revalidate"; bagian kedua boleh dihilangkan
+func getCachePolicyEnv(key string, defaultValue CachePolicy) CachePolicy {
+	value := getEnv(key, "")
+	if value == "" {
+		return defaultValue
+	}
+	maxAgeValue, swrValue, hasSWR := strings.Cut(value, ",")
+	policy := CachePolicy{}
+	var err error
+	if policy.MaxAge, err = time.ParseDuration(strings.TrimSpace(maxAgeValue)); err == nil && hasSWR {
+		policy.StaleWhileRevalidate, err = time.ParseDuration(strings.TrimSpace(swrValue))
+	}
+	if err != nil || policy.MaxAge < 0 || policy.StaleWhileRevalidate < 0 {
+		log.Printf("Nilai %s tidak valid (%q), memakai default %s,%s", key, value, defaultValue.MaxAge, defaultValue.StaleWhileRevalidate)
+		return defaultValue
+	}
+	return policy
+}
+
+func getListEnv(key string, defaultValue []string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return defaultValue
+	}
+	return values
+}
+
+// defaultAllowedHosts allows the host of the base URL with and without www.
+func defaultAllowedHosts(baseURL string) []string {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Hostname() == "" {
+		return []string{"dramaqu.ad", "www.dramaqu.ad"}
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	return []string{host, "www." + host}
+}
@@ -56,15 +56,25 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     }
                 }
@@ -77,7 +87,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/msgpack"
                 ],
                 "tags": [
                     "anime-terbaru"
@@ -90,6 +103,18 @@ const docTemplate = `{
                         "description": "Nomor halaman",
                         "name": "page",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson",
+                            "msgpack"
+                        ],
+                        "type": "string",
+                        "description": "Format output (default JSON, juga lewat header Accept)",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -102,15 +127,96 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/dramas/{slug}/episodes/{n}": {
+            "get": {
+                "description": "Mengambil detail episode berdasarkan slug drama dan nomor episode. URL episode dibangun dari BASE_URL; parameter n juga menerima format episode_slug ('{slug}-episode-{n}')",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "episode-detail"
+                ],
+                "summary": "Get episode detail by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slug drama (contoh: 'love-in-the-air')",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nomor episode atau episode_slug (contoh: '3' atau 'love-in-the-air-episode-3')",
+                        "name": "n",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Resolve setiap server streaming menjadi URL HLS/MP4 langsung dengan proxy_url bertanda tangan",
+                        "name": "resolve",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Hanya tampilkan server streaming yang lolos health check",
+                        "name": "only_alive",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EpisodeDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "504": {
+                        "description": "Gateway Timeout",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     }
                 }
@@ -136,6 +242,20 @@ const docTemplate = `{
                         "name": "episode_url",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Resolve setiap server streaming menjadi URL HLS/MP4 langsung dengan proxy_url bertanda tangan",
+                        "name": "resolve",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Hanya tampilkan server streaming yang lolos health check",
+                        "name": "only_alive",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -148,15 +268,149 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/genres": {
+            "get": {
+                "description": "Mengambil daftar semua genre beserta jumlah drama di setiap genre",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Get genres",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson",
+                            "msgpack"
+                        ],
+                        "type": "string",
+                        "description": "Format output (default JSON, juga lewat header Accept)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GenreListResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/genres/{slug}": {
+            "get": {
+                "description": "Mengambil daftar drama pada genre tertentu dengan pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Get dramas by genre",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slug genre (contoh: 'action', 'romance')",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Nomor halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson",
+                            "msgpack"
+                        ],
+                        "type": "string",
+                        "description": "Format output (default JSON, juga lewat header Accept)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DramaListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "504": {
+                        "description": "Gateway Timeout",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     }
                 }
@@ -182,11 +436,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.FinalResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     }
                 }
@@ -194,7 +453,7 @@ const docTemplate = `{
         },
         "/api/v1/jadwal-rilis": {
             "get": {
-                "description": "Mengambil jadwal rilis anime per hari",
+                "description": "Mengambil jadwal rilis anime per hari. Hari dan jam rilis adalah perkiraan (situs sumber tidak mencantumkan jadwal) yang diturunkan dari slug, sehingga sama di setiap request dan di semua endpoint jadwal; response ditandai estimated=true. Dengan tz, entry dipindahkan ke hari dan jam rilis di zona waktu tersebut; next_air adalah tayangan berikutnya dalam ISO-8601",
                 "consumes": [
                     "application/json"
                 ],
@@ -205,6 +464,14 @@ const docTemplate = `{
                     "jadwal-rilis"
                 ],
                 "summary": "Get jadwal rilis",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Zona waktu IANA untuk hari, release_time dan next_air (default Asia/Jakarta)",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -212,81 +479,114 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ReleaseScheduleResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/jadwal-rilis/{day}": {
+        "/api/v1/jadwal-rilis.ics": {
             "get": {
-                "description": "Mengambil jadwal rilis anime untuk hari tertentu",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Jadwal rilis semua hari sebagai event mingguan berulang (RRULE WEEKLY) di zona Asia/Jakarta dengan VTIMEZONE, satu event per drama. URL dan cover ada di deskripsi event",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
                     "jadwal-rilis"
                 ],
-                "summary": "Get jadwal rilis by day",
+                "summary": "Export jadwal rilis ke iCalendar",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Nama hari (monday, tuesday, wednesday, thursday, friday, saturday, sunday)",
-                        "name": "day",
-                        "in": "path",
-                        "required": true
+                        "description": "Hanya ekspor drama ini (slug dipisah koma)",
+                        "name": "slugs",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Dokumen iCalendar",
                         "schema": {
-                            "$ref": "#/definitions/models.ScheduleByDayResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "504": {
+                        "description": "Gateway Timeout",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/movie": {
+        "/api/v1/jadwal-rilis/{day}": {
             "get": {
-                "description": "Mengambil daftar film dengan pagination",
+                "description": "Mengambil jadwal rilis anime untuk hari tertentu, dengan perkiraan hari dan jam yang sama seperti /jadwal-rilis (ditandai estimated=true). Dengan akhiran .ics (contoh /jadwal-rilis/monday.ics) jadwal dikirim sebagai iCalendar seperti /jadwal-rilis.ics",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "text/calendar"
                 ],
                 "tags": [
-                    "movie"
+                    "jadwal-rilis"
                 ],
-                "summary": "Get movies",
+                "summary": "Get jadwal rilis by day",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Nomor halaman",
-                        "name": "page",
+                        "type": "string",
+                        "description": "Nama hari dalam bahasa Inggris atau Indonesia (monday ... sunday, senin ... minggu)",
+                        "name": "day",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Zona waktu IANA untuk hari, release_time dan next_air (default Asia/Jakarta)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson",
+                            "msgpack"
+                        ],
+                        "type": "string",
+                        "description": "Format output (default JSON, juga lewat header Accept)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Khusus .ics: hanya ekspor drama ini (slug dipisah koma)",
+                        "name": "slugs",
                         "in": "query"
                     }
                 ],
@@ -294,51 +594,77 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.DramaListResponse"
+                            "$ref": "#/definitions/models.ScheduleByDayResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "504": {
+                        "description": "Gateway Timeout",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/search": {
+        "/api/v1/jadwal-rilis/{day}.ics": {
             "get": {
-                "description": "Mencari anime berdasarkan judul",
+                "description": "Mengambil jadwal rilis anime untuk hari tertentu, dengan perkiraan hari dan jam yang sama seperti /jadwal-rilis (ditandai estimated=true). Dengan akhiran .ics (contoh /jadwal-rilis/monday.ics) jadwal dikirim sebagai iCalendar seperti /jadwal-rilis.ics",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/msgpack",
+                    "text/calendar"
                 ],
                 "tags": [
-                    "search"
+                    "jadwal-rilis"
                 ],
-                "summary": "Search anime",
+                "summary": "Get jadwal rilis by day",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Query pencarian",
-                        "name": "query",
-                        "in": "query",
+                        "description": "Nama hari dalam bahasa Inggris atau Indonesia (monday ... sunday, senin ... minggu)",
+                        "name": "day",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Nomor halaman (default: 1)",
-                        "name": "page",
+                        "type": "string",
+                        "description": "Zona waktu IANA untuk hari, release_time dan next_air (default Asia/Jakarta)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson",
+                            "msgpack"
+                        ],
+                        "type": "string",
+                        "description": "Format output (default JSON, juga lewat header Accept)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Khusus .ics: hanya ekspor drama ini (slug dipisah koma)",
+                        "name": "slugs",
                         "in": "query"
                     }
                 ],
@@ -346,106 +672,2331 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SearchResponse"
+                            "$ref": "#/definitions/models.ScheduleByDayResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "504": {
+                        "description": "Gateway Timeout",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/apierror.Response"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.AnimeInfo": {
-            "type": "object",
-            "properties": {
-                "genres": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "synopsis": {
-                    "type": "string"
+        },
+        "/api/v1/movie": {
+            "get": {
+                "description": "Mengambil daftar film dengan pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "movie"
+                ],
+                "summary": "Get movies",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Nomor halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson",
+                            "msgpack"
+                        ],
+                        "type": "string",
+                        "description": "Format output (default JSON, juga lewat header Accept)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DramaListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/upcoming": {
+            "get": {
+                "description": "Drama ongoing yang tayang dalam beberapa jam ke depan, paling cepat lebih dulu, dengan nomor episode berikutnya (episode terbaru + 1), jam tayang (ISO-8601) dan hitung mundur dalam detik sejak generated_at. Jam tayang adalah perkiraan dari jadwal rilis (situs sumber tidak mencantumkan jadwal), ditandai estimated=true; nilainya sama di setiap request",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "jadwal-rilis"
+                ],
+                "summary": "Get episode yang akan tayang",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rentang waktu ke depan dalam jam (1-168, default: 24)",
+                        "name": "hours",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Zona waktu IANA untuk release_time dan air_time (default Asia/Jakarta)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson",
+                            "msgpack"
+                        ],
+                        "type": "string",
+                        "description": "Format output (default JSON, juga lewat header Accept)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UpcomingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search": {
+            "get": {
+                "description": "Mencari anime berdasarkan judul",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search anime",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Query pencarian",
+                        "name": "query",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Nomor halaman (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson",
+                            "msgpack"
+                        ],
+                        "type": "string",
+                        "description": "Format output (default JSON, juga lewat header Accept)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search/suggest": {
+            "get": {
+                "description": "Memberikan saran judul secara cepat dari data yang sudah pernah di-scrape (tanpa scraping ulang)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search suggestions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Awalan judul yang sedang diketik",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah saran maksimal (1-20, default: 8)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson",
+                            "msgpack"
+                        ],
+                        "type": "string",
+                        "description": "Format output (default JSON, juga lewat header Accept)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuggestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stream/proxy": {
+            "get": {
+                "description": "Meneruskan playlist m3u8, segmen, key atau subtitle dari host hasil resolver dengan header yang dibutuhkan (Referer/Origin). URI di dalam playlist di-rewrite agar melewati proxy, dan segmen di-stream tanpa di-buffer penuh.",
+                "produces": [
+                    "application/vnd.apple.mpegurl",
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "stream"
+                ],
+                "summary": "Proxy HLS stream",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "URL media hasil resolver (proxy_url dari stream/resolve)",
+                        "name": "url",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Unix timestamp kedaluwarsa link",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanda tangan HMAC link",
+                        "name": "sig",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "206": {
+                        "description": "Partial Content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stream/resolve": {
+            "get": {
+                "description": "Mengubah URL iframe player (embed) menjadi URL HLS/MP4 langsung beserta subtitle dan header yang dibutuhkan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream"
+                ],
+                "summary": "Resolve embed player",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "URL embed player (contoh: streaming_url dari episode-detail)",
+                        "name": "url",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StreamResolveResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/subtitles/convert": {
+            "get": {
+                "description": "Mengonversi subtitle SRT/ASS menjadi WebVTT (atau sebaliknya) dengan offset waktu opsional. GET mengambil subtitle dari URL hasil resolver, POST menerima isi file pada body.",
+                "consumes": [
+                    "text/plain"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "subtitles"
+                ],
+                "summary": "Convert subtitle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "URL subtitle hasil resolver (wajib untuk GET)",
+                        "name": "url",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "vtt",
+                        "description": "Format tujuan: vtt, srt atau ass",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format asal: srt, vtt atau ass (default: deteksi otomatis)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Geser waktu dalam detik (contoh 2.5 atau -1) atau durasi Go (contoh 1500ms)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Isi subtitle hasil konversi",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Mengonversi subtitle SRT/ASS menjadi WebVTT (atau sebaliknya) dengan offset waktu opsional. GET mengambil subtitle dari URL hasil resolver, POST menerima isi file pada body.",
+                "consumes": [
+                    "text/plain"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "subtitles"
+                ],
+                "summary": "Convert subtitle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "URL subtitle hasil resolver (wajib untuk GET)",
+                        "name": "url",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "vtt",
+                        "description": "Format tujuan: vtt, srt atau ass",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format asal: srt, vtt atau ass (default: deteksi otomatis)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Geser waktu dalam detik (contoh 2.5 atau -1) atau durasi Go (contoh 1500ms)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Isi subtitle hasil konversi",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/dramas/{slug}": {
+            "get": {
+                "description": "Detail drama: info, rating, daftar episode dan rekomendasi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get drama detail (v2)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slug drama",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v2.DramaDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/dramas/{slug}/episodes/{n}": {
+            "get": {
+                "description": "Detail episode: server streaming, subtitle, link download (list datar per format dan kualitas) dan navigasi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get episode detail (v2)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slug drama",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nomor episode atau episode slug ('{slug}-episode-{n}')",
+                        "name": "n",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Resolve server streaming menjadi URL media langsung",
+                        "name": "resolve",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Hanya server yang lolos health check",
+                        "name": "only_alive",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v2.Episode"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/genres": {
+            "get": {
+                "description": "Daftar genre beserta jumlah drama",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get genres (v2)",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson",
+                            "msgpack"
+                        ],
+                        "type": "string",
+                        "description": "Format output (default JSON, juga lewat header Accept)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v2.Genre"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/genres/{slug}": {
+            "get": {
+                "description": "Daftar drama pada satu genre",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get dramas by genre (v2)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slug genre",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Nomor halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson",
+                            "msgpack"
+                        ],
+                        "type": "string",
+                        "description": "Format output (default JSON, juga lewat header Accept)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v2.Drama"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/home": {
+            "get": {
+                "description": "Data homepage dengan field berbahasa Inggris: top, latest_episodes, movies, schedule (per hari) dan status tiap section",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get homepage data (v2)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v2.Home"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/movies": {
+            "get": {
+                "description": "Daftar film",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get movies (v2)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Nomor halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson",
+                            "msgpack"
+                        ],
+                        "type": "string",
+                        "description": "Format output (default JSON, juga lewat header Accept)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v2.Drama"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/ongoing": {
+            "get": {
+                "description": "Daftar drama yang sedang tayang beserta episode terbarunya",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get ongoing dramas (v2)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Nomor halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson",
+                            "msgpack"
+                        ],
+                        "type": "string",
+                        "description": "Format output (default JSON, juga lewat header Accept)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v2.Drama"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/schedule": {
+            "get": {
+                "description": "Jadwal rilis mingguan dengan key hari dalam huruf kecil (monday ... sunday)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get weekly release schedule (v2)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Zona waktu IANA untuk day, release_time dan next_air (default Asia/Jakarta)",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "array",
+                                                "items": {
+                                                    "$ref": "#/definitions/v2.ScheduleEntry"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/schedule/{day}": {
+            "get": {
+                "description": "Jadwal rilis untuk satu hari",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get release schedule by day (v2)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nama hari dalam bahasa Inggris atau Indonesia (monday ... sunday, senin ... minggu)",
+                        "name": "day",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Zona waktu IANA untuk day, release_time dan next_air (default Asia/Jakarta)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson",
+                            "msgpack"
+                        ],
+                        "type": "string",
+                        "description": "Format output (default JSON, juga lewat header Accept)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v2.ScheduleEntry"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/search": {
+            "get": {
+                "description": "Mencari drama berdasarkan judul",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Search dramas (v2)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Query pencarian",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Nomor halaman",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson",
+                            "msgpack"
+                        ],
+                        "type": "string",
+                        "description": "Format output (default JSON, juga lewat header Accept)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v2.Drama"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/search/suggest": {
+            "get": {
+                "description": "Saran judul dari data yang sudah pernah di-scrape",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Search suggestions (v2)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Awalan judul",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 8,
+                        "description": "Jumlah saran maksimal (1-20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson",
+                            "msgpack"
+                        ],
+                        "type": "string",
+                        "description": "Format output (default JSON, juga lewat header Accept)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v2.Drama"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v2.Envelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/drama/{file}": {
+            "get": {
+                "description": "Feed RSS 2.0 ({slug}.xml) atau Atom ({slug}.atom) berisi semua episode satu drama, terbaru lebih dulu. Mendukung ETag dan Last-Modified",
+                "produces": [
+                    "application/rss+xml",
+                    "application/atom+xml"
+                ],
+                "tags": [
+                    "feeds"
+                ],
+                "summary": "Feed episode drama",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slug drama dengan ekstensi feed, contoh judul-drama.xml atau judul-drama.atom",
+                        "name": "file",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dokumen RSS atau Atom",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "Feed tidak berubah"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/ongoing.atom": {
+            "get": {
+                "description": "Feed RSS 2.0 (.xml) atau Atom (.atom) berisi episode terbaru setiap drama ongoing. GUID item adalah URL episode, pubDate hanya dikirim jika tanggal rilis diketahui dari halaman sumber, cover dikirim sebagai enclosure. Mendukung ETag dan Last-Modified",
+                "produces": [
+                    "application/rss+xml",
+                    "application/atom+xml"
+                ],
+                "tags": [
+                    "feeds"
+                ],
+                "summary": "Feed episode terbaru",
+                "responses": {
+                    "200": {
+                        "description": "Dokumen RSS atau Atom",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "Feed tidak berubah"
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/ongoing.xml": {
+            "get": {
+                "description": "Feed RSS 2.0 (.xml) atau Atom (.atom) berisi episode terbaru setiap drama ongoing. GUID item adalah URL episode, pubDate hanya dikirim jika tanggal rilis diketahui dari halaman sumber, cover dikirim sebagai enclosure. Mendukung ETag dan Last-Modified",
+                "produces": [
+                    "application/rss+xml",
+                    "application/atom+xml"
+                ],
+                "tags": [
+                    "feeds"
+                ],
+                "summary": "Feed episode terbaru",
+                "responses": {
+                    "200": {
+                        "description": "Dokumen RSS atau Atom",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "Feed tidak berubah"
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/apierror.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apierror.Code": {
+            "type": "string",
+            "enum": [
+                "INVALID_PARAM",
+                "NOT_FOUND",
+                "FORBIDDEN",
+                "UPSTREAM_UNAVAILABLE",
+                "UPSTREAM_CHANGED",
+                "RATE_LIMITED",
+                "TIMEOUT",
+                "INTERNAL"
+            ],
+            "x-enum-varnames": [
+                "CodeInvalidParam",
+                "CodeNotFound",
+                "CodeForbidden",
+                "CodeUpstreamUnavailable",
+                "CodeUpstreamChanged",
+                "CodeRateLimited",
+                "CodeTimeout",
+                "CodeInternal"
+            ]
+        },
+        "apierror.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/apierror.Code"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AnimeInfo": {
+            "type": "object",
+            "properties": {
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "synopsis": {
+                    "type": "string"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DetailResponse": {
+            "type": "object",
+            "properties": {
+                "anime_slug": {
+                    "type": "string"
+                },
+                "confidence_score": {
+                    "type": "number"
+                },
+                "cover": {
+                    "type": "string"
+                },
+                "details": {
+                    "$ref": "#/definitions/models.DetailsObject"
+                },
+                "episode_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EpisodeItem"
+                    }
+                },
+                "genre": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "judul": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "penonton": {
+                    "type": "string"
+                },
+                "rating": {
+                    "$ref": "#/definitions/models.RatingObject"
+                },
+                "recommendations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecommendationItem"
+                    }
+                },
+                "sinopsis": {
+                    "type": "string"
+                },
+                "skor": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tipe": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DetailsObject": {
+            "type": "object",
+            "properties": {
+                "Duration": {
+                    "type": "string"
+                },
+                "English": {
+                    "type": "string"
+                },
+                "Japanese": {
+                    "type": "string"
+                },
+                "Producers": {
+                    "type": "string"
+                },
+                "Released:": {
+                    "type": "string"
+                },
+                "Season": {
+                    "type": "string"
+                },
+                "Source": {
+                    "type": "string"
+                },
+                "Status": {
+                    "type": "string"
+                },
+                "Studio": {
+                    "type": "string"
+                },
+                "Total Episode": {
+                    "type": "string"
+                },
+                "Type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DownloadLinks": {
+            "type": "object",
+            "properties": {
+                "MKV": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/models.DownloadProvider"
+                        }
+                    }
+                },
+                "MP4": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/models.DownloadProvider"
+                        }
+                    }
+                },
+                "x265 [Mode Irit Kuota tapi Kualitas Sama Beningnya]": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/models.DownloadProvider"
+                        }
+                    }
+                }
+            }
+        },
+        "models.DownloadProvider": {
+            "type": "object",
+            "properties": {
+                "provider": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DramaDetail": {
+            "type": "object",
+            "properties": {
+                "anime_slug": {
+                    "type": "string"
+                },
+                "cover": {
+                    "type": "string"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "judul": {
+                    "type": "string"
+                },
+                "sinopsis": {
+                    "type": "string"
+                },
+                "skor": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tanggal": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "views": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DramaEntry": {
+            "type": "object",
+            "properties": {
+                "anime_slug": {
+                    "type": "string"
+                },
+                "cover": {
+                    "type": "string"
+                },
+                "episode": {
+                    "type": "string"
+                },
+                "judul": {
+                    "type": "string"
+                },
+                "rilis": {
+                    "type": "string"
+                },
+                "uploader": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DramaListResponse": {
+            "type": "object",
+            "properties": {
+                "confidence_score": {
+                    "type": "number"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DramaDetail"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EpisodeDetailResponse": {
+            "type": "object",
+            "properties": {
+                "anime_info": {
+                    "$ref": "#/definitions/models.AnimeInfo"
+                },
+                "confidence_score": {
+                    "type": "number"
+                },
+                "download_links": {
+                    "$ref": "#/definitions/models.DownloadLinks"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "navigation": {
+                    "$ref": "#/definitions/models.Navigation"
+                },
+                "nonce_strategy": {
+                    "type": "string"
+                },
+                "other_episodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OtherEpisode"
+                    }
+                },
+                "release_info": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "streaming_servers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StreamingServer"
+                    }
+                },
+                "subtitles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StreamSubtitle"
+                    }
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ExtractionWarning"
+                    }
+                }
+            }
+        },
+        "models.EpisodeItem": {
+            "type": "object",
+            "properties": {
+                "episode": {
+                    "type": "string"
+                },
+                "episode_slug": {
+                    "type": "string"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ExtractionWarning": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "strategy": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FinalResponse": {
+            "type": "object",
+            "properties": {
+                "confidence_score": {
+                    "type": "number"
+                },
+                "jadwal_rilis": {
+                    "$ref": "#/definitions/models.JadwalRilis"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "movies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MovieItem"
+                    }
+                },
+                "new_eps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NewEpsItem"
+                    }
+                },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SectionStatus"
+                    }
+                },
+                "source": {
+                    "type": "string"
+                },
+                "top10": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Top10Item"
+                    }
+                }
+            }
+        },
+        "models.GenreItem": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GenreListResponse": {
+            "type": "object",
+            "properties": {
+                "confidence_score": {
+                    "type": "number"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GenreItem"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.JadwalItem": {
+            "type": "object",
+            "properties": {
+                "anime_slug": {
+                    "type": "string"
+                },
+                "cover_url": {
+                    "type": "string"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "release_time": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.JadwalRilis": {
+            "type": "object",
+            "properties": {
+                "Friday": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JadwalItem"
+                    }
+                },
+                "Monday": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JadwalItem"
+                    }
+                },
+                "Saturday": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JadwalItem"
+                    }
+                },
+                "Sunday": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JadwalItem"
+                    }
+                },
+                "Thursday": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JadwalItem"
+                    }
+                },
+                "Tuesday": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JadwalItem"
+                    }
+                },
+                "Wednesday": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JadwalItem"
+                    }
+                }
+            }
+        },
+        "models.MediaSource": {
+            "type": "object",
+            "properties": {
+                "proxy_url": {
+                    "description": "ProxyURL memutar source melalui /api/v1/stream/proxy dengan header yang dibutuhkan",
+                    "type": "string"
+                },
+                "quality": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MovieItem": {
+            "type": "object",
+            "properties": {
+                "anime_slug": {
+                    "type": "string"
+                },
+                "cover": {
+                    "type": "string"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "judul": {
+                    "type": "string"
+                },
+                "tanggal": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Navigation": {
+            "type": "object",
+            "properties": {
+                "all_episodes_url": {
+                    "type": "string"
+                },
+                "next_episode_url": {
+                    "type": "string"
+                },
+                "previous_episode_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.NewEpsItem": {
+            "type": "object",
+            "properties": {
+                "anime_slug": {
+                    "type": "string"
+                },
+                "cover": {
+                    "type": "string"
+                },
+                "episode": {
+                    "type": "string"
+                },
+                "judul": {
+                    "type": "string"
+                },
+                "rilis": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OngoingDramaResponse": {
+            "type": "object",
+            "properties": {
+                "confidence_score": {
+                    "type": "number"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DramaEntry"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OtherEpisode": {
+            "type": "object",
+            "properties": {
+                "release_date": {
+                    "type": "string"
                 },
                 "thumbnail_url": {
                     "type": "string"
                 },
                 "title": {
                     "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "models.DetailResponse": {
+        "models.RatingObject": {
+            "type": "object",
+            "properties": {
+                "score": {
+                    "type": "string"
+                },
+                "users": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RecommendationItem": {
+            "type": "object",
+            "properties": {
+                "anime_slug": {
+                    "type": "string"
+                },
+                "cover_url": {
+                    "type": "string"
+                },
+                "episode": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReleaseEntry": {
             "type": "object",
             "properties": {
                 "anime_slug": {
                     "type": "string"
                 },
+                "cover_url": {
+                    "type": "string"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "next_air": {
+                    "description": "NextAir is the next broadcast as ISO-8601 in the requested timezone",
+                    "type": "string"
+                },
+                "release_time": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReleaseScheduleResponse": {
+            "type": "object",
+            "properties": {
                 "confidence_score": {
                     "type": "number"
                 },
-                "cover": {
+                "data": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/models.ReleaseEntry"
+                        }
+                    }
+                },
+                "estimated": {
+                    "description": "Estimated is always true: hari dan jam rilis berasal dari perkiraan jadwal, bukan jadwal resmi",
+                    "type": "boolean"
+                },
+                "message": {
                     "type": "string"
                 },
-                "details": {
-                    "$ref": "#/definitions/models.DetailsObject"
+                "source": {
+                    "type": "string"
                 },
-                "episode_list": {
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ResolvedStream": {
+            "type": "object",
+            "properties": {
+                "embed_url": {
+                    "type": "string"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "proxy_expires_at": {
+                    "description": "ProxyExpiresAt adalah waktu kedaluwarsa tanda tangan proxy_url (RFC3339)",
+                    "type": "string"
+                },
+                "resolver": {
+                    "type": "string"
+                },
+                "sources": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.EpisodeItem"
+                        "$ref": "#/definitions/models.MediaSource"
                     }
                 },
-                "genre": {
+                "subtitles": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/models.StreamSubtitle"
                     }
+                }
+            }
+        },
+        "models.ScheduleByDayResponse": {
+            "type": "object",
+            "properties": {
+                "confidence_score": {
+                    "type": "number"
                 },
-                "judul": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ScheduleEntry"
+                    }
+                },
+                "estimated": {
+                    "description": "Estimated is always true: hari dan jam rilis berasal dari perkiraan jadwal, bukan jadwal resmi",
+                    "type": "boolean"
                 },
                 "message": {
                     "type": "string"
                 },
-                "penonton": {
+                "source": {
                     "type": "string"
                 },
-                "rating": {
-                    "$ref": "#/definitions/models.RatingObject"
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ScheduleEntry": {
+            "type": "object",
+            "properties": {
+                "anime_slug": {
+                    "type": "string"
                 },
-                "recommendations": {
+                "cover_url": {
+                    "type": "string"
+                },
+                "genres": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.RecommendationItem"
+                        "type": "string"
                     }
                 },
-                "sinopsis": {
+                "next_air": {
+                    "description": "NextAir is the next broadcast as ISO-8601 in the requested timezone",
                     "type": "string"
                 },
-                "skor": {
+                "release_time": {
                     "type": "string"
                 },
-                "source": {
+                "score": {
                     "type": "string"
                 },
-                "status": {
+                "title": {
                     "type": "string"
                 },
-                "tipe": {
+                "type": {
                     "type": "string"
                 },
                 "url": {
@@ -453,126 +3004,179 @@ const docTemplate = `{
                 }
             }
         },
-        "models.DetailsObject": {
+        "models.SearchDetail": {
             "type": "object",
             "properties": {
-                "Duration": {
+                "anime_slug": {
                     "type": "string"
                 },
-                "English": {
+                "cover": {
                     "type": "string"
                 },
-                "Japanese": {
+                "genre": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "judul": {
                     "type": "string"
                 },
-                "Producers": {
+                "penonton": {
                     "type": "string"
                 },
-                "Released:": {
+                "sinopsis": {
                     "type": "string"
                 },
-                "Season": {
+                "skor": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tipe": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SearchResponse": {
+            "type": "object",
+            "properties": {
+                "confidence_score": {
+                    "type": "number"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SearchDetail"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "source": {
                     "type": "string"
+                }
+            }
+        },
+        "models.SectionStatus": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "integer"
                 },
-                "Source": {
+                "error": {
                     "type": "string"
                 },
-                "Status": {
-                    "type": "string"
+                "items": {
+                    "type": "integer"
                 },
-                "Studio": {
+                "name": {
                     "type": "string"
                 },
-                "Total Episode": {
+                "source": {
                     "type": "string"
                 },
-                "Type": {
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "models.DownloadLinks": {
+        "models.ServerHealth": {
             "type": "object",
             "properties": {
-                "MKV": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "array",
-                        "items": {
-                            "$ref": "#/definitions/models.DownloadProvider"
-                        }
-                    }
+                "error": {
+                    "type": "string"
                 },
-                "MP4": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "array",
-                        "items": {
-                            "$ref": "#/definitions/models.DownloadProvider"
-                        }
-                    }
+                "last_checked": {
+                    "type": "string"
                 },
-                "x265 [Mode Irit Kuota tapi Kualitas Sama Beningnya]": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "array",
-                        "items": {
-                            "$ref": "#/definitions/models.DownloadProvider"
-                        }
-                    }
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
                 }
             }
         },
-        "models.DownloadProvider": {
+        "models.StreamResolveResponse": {
             "type": "object",
             "properties": {
-                "provider": {
+                "confidence_score": {
+                    "type": "number"
+                },
+                "data": {
+                    "$ref": "#/definitions/models.ResolvedStream"
+                },
+                "message": {
                     "type": "string"
                 },
-                "url": {
+                "source": {
                     "type": "string"
                 }
             }
         },
-        "models.DramaDetail": {
+        "models.StreamSubtitle": {
             "type": "object",
             "properties": {
-                "anime_slug": {
+                "format": {
                     "type": "string"
                 },
-                "cover": {
+                "label": {
                     "type": "string"
                 },
-                "genres": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "language": {
+                    "type": "string"
                 },
-                "judul": {
+                "proxy_url": {
                     "type": "string"
                 },
-                "sinopsis": {
+                "url": {
                     "type": "string"
+                }
+            }
+        },
+        "models.StreamingServer": {
+            "type": "object",
+            "properties": {
+                "health": {
+                    "$ref": "#/definitions/models.ServerHealth"
                 },
-                "skor": {
+                "label": {
                     "type": "string"
                 },
-                "status": {
+                "quality": {
                     "type": "string"
                 },
-                "tanggal": {
+                "resolve_error": {
                     "type": "string"
                 },
-                "url": {
+                "resolved": {
+                    "description": "Resolved hanya diisi saat request memakai ?resolve=true",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ResolvedStream"
+                        }
+                    ]
+                },
+                "server_id": {
                     "type": "string"
                 },
-                "views": {
+                "server_name": {
+                    "type": "string"
+                },
+                "streaming_url": {
                     "type": "string"
                 }
             }
         },
-        "models.DramaEntry": {
+        "models.SuggestItem": {
             "type": "object",
             "properties": {
                 "anime_slug": {
@@ -581,24 +3185,15 @@ const docTemplate = `{
                 "cover": {
                     "type": "string"
                 },
-                "episode": {
-                    "type": "string"
-                },
                 "judul": {
                     "type": "string"
                 },
-                "rilis": {
-                    "type": "string"
-                },
-                "uploader": {
-                    "type": "string"
-                },
                 "url": {
                     "type": "string"
                 }
             }
         },
-        "models.DramaListResponse": {
+        "models.SuggestResponse": {
             "type": "object",
             "properties": {
                 "confidence_score": {
@@ -607,7 +3202,7 @@ const docTemplate = `{
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.DramaDetail"
+                        "$ref": "#/definitions/models.SuggestItem"
                     }
                 },
                 "message": {
@@ -618,60 +3213,59 @@ const docTemplate = `{
                 }
             }
         },
-        "models.EpisodeDetailResponse": {
+        "models.Top10Item": {
             "type": "object",
             "properties": {
-                "anime_info": {
-                    "$ref": "#/definitions/models.AnimeInfo"
-                },
-                "confidence_score": {
-                    "type": "number"
-                },
-                "download_links": {
-                    "$ref": "#/definitions/models.DownloadLinks"
-                },
-                "message": {
+                "anime_slug": {
                     "type": "string"
                 },
-                "navigation": {
-                    "$ref": "#/definitions/models.Navigation"
+                "cover": {
+                    "type": "string"
                 },
-                "other_episodes": {
+                "genres": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.OtherEpisode"
+                        "type": "string"
                     }
                 },
-                "release_info": {
-                    "type": "string"
-                },
-                "source": {
+                "judul": {
                     "type": "string"
                 },
-                "streaming_servers": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.StreamingServer"
-                    }
-                },
-                "thumbnail_url": {
+                "rating": {
                     "type": "string"
                 },
-                "title": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "models.EpisodeItem": {
+        "models.UpcomingEntry": {
             "type": "object",
             "properties": {
-                "episode": {
+                "air_time": {
                     "type": "string"
                 },
-                "episode_slug": {
+                "anime_slug": {
                     "type": "string"
                 },
-                "release_date": {
+                "countdown_seconds": {
+                    "type": "integer"
+                },
+                "cover_url": {
+                    "type": "string"
+                },
+                "episode_url": {
+                    "description": "EpisodeURL is the expected URL of NextEpisode once it is released",
+                    "type": "string"
+                },
+                "latest_episode": {
+                    "description": "LatestEpisode and NextEpisode are 0 when the episode text has no number",
+                    "type": "integer"
+                },
+                "next_episode": {
+                    "type": "integer"
+                },
+                "release_time": {
                     "type": "string"
                 },
                 "title": {
@@ -682,47 +3276,71 @@ const docTemplate = `{
                 }
             }
         },
-        "models.FinalResponse": {
+        "models.UpcomingResponse": {
             "type": "object",
             "properties": {
                 "confidence_score": {
                     "type": "number"
                 },
-                "jadwal_rilis": {
-                    "$ref": "#/definitions/models.JadwalRilis"
-                },
-                "message": {
-                    "type": "string"
-                },
-                "movies": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.MovieItem"
+                        "$ref": "#/definitions/models.UpcomingEntry"
                     }
                 },
-                "new_eps": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.NewEpsItem"
-                    }
+                "estimated": {
+                    "description": "Estimated is always true: air_time berasal dari perkiraan jadwal, bukan jadwal resmi",
+                    "type": "boolean"
+                },
+                "generated_at": {
+                    "description": "GeneratedAt is the moment countdown_seconds is measured from (ISO-8601)",
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
                 },
                 "source": {
                     "type": "string"
                 },
-                "top10": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Top10Item"
-                    }
+                "timezone": {
+                    "type": "string"
                 }
             }
         },
-        "models.JadwalItem": {
+        "v2.Confidence": {
             "type": "object",
             "properties": {
-                "anime_slug": {
+                "message": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "v2.Download": {
+            "type": "object",
+            "properties": {
+                "format": {
                     "type": "string"
                 },
+                "provider": {
+                    "type": "string"
+                },
+                "quality": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "v2.Drama": {
+            "type": "object",
+            "properties": {
                 "cover_url": {
                     "type": "string"
                 },
@@ -732,12 +3350,24 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
-                "release_time": {
+                "latest_episode": {
+                    "type": "string"
+                },
+                "released": {
                     "type": "string"
                 },
                 "score": {
                     "type": "string"
                 },
+                "slug": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "synopsis": {
+                    "type": "string"
+                },
                 "title": {
                     "type": "string"
                 },
@@ -746,180 +3376,213 @@ const docTemplate = `{
                 },
                 "url": {
                     "type": "string"
+                },
+                "views": {
+                    "type": "string"
                 }
             }
         },
-        "models.JadwalRilis": {
+        "v2.DramaDetail": {
             "type": "object",
             "properties": {
-                "Friday": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.JadwalItem"
-                    }
-                },
-                "Monday": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.JadwalItem"
-                    }
-                },
-                "Saturday": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.JadwalItem"
-                    }
-                },
-                "Sunday": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.JadwalItem"
-                    }
-                },
-                "Thursday": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.JadwalItem"
-                    }
+                "cover_url": {
+                    "type": "string"
                 },
-                "Tuesday": {
+                "episodes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.JadwalItem"
+                        "$ref": "#/definitions/v2.EpisodeRef"
                     }
                 },
-                "Wednesday": {
+                "genres": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.JadwalItem"
+                        "type": "string"
                     }
-                }
-            }
-        },
-        "models.MovieItem": {
-            "type": "object",
-            "properties": {
-                "anime_slug": {
-                    "type": "string"
                 },
-                "cover": {
+                "info": {
+                    "$ref": "#/definitions/v2.DramaInfo"
+                },
+                "latest_episode": {
                     "type": "string"
                 },
-                "genres": {
+                "rating": {
+                    "$ref": "#/definitions/v2.Rating"
+                },
+                "recommendations": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/v2.Drama"
                     }
                 },
-                "judul": {
+                "released": {
                     "type": "string"
                 },
-                "tanggal": {
+                "score": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "synopsis": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
                     "type": "string"
                 },
                 "url": {
                     "type": "string"
+                },
+                "viewers": {
+                    "type": "string"
+                },
+                "views": {
+                    "type": "string"
                 }
             }
         },
-        "models.Navigation": {
+        "v2.DramaInfo": {
             "type": "object",
             "properties": {
-                "all_episodes_url": {
+                "duration": {
                     "type": "string"
                 },
-                "next_episode_url": {
+                "english_title": {
                     "type": "string"
                 },
-                "previous_episode_url": {
+                "native_title": {
                     "type": "string"
-                }
-            }
-        },
-        "models.NewEpsItem": {
-            "type": "object",
-            "properties": {
-                "anime_slug": {
+                },
+                "producers": {
                     "type": "string"
                 },
-                "cover": {
+                "released": {
                     "type": "string"
                 },
-                "episode": {
+                "season": {
                     "type": "string"
                 },
-                "judul": {
+                "source": {
                     "type": "string"
                 },
-                "rilis": {
+                "status": {
                     "type": "string"
                 },
-                "url": {
+                "studio": {
+                    "type": "string"
+                },
+                "total_episodes": {
+                    "type": "string"
+                },
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "models.OngoingDramaResponse": {
+        "v2.Envelope": {
             "type": "object",
             "properties": {
-                "confidence_score": {
-                    "type": "number"
-                },
-                "data": {
+                "data": {},
+                "errors": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.DramaEntry"
+                        "$ref": "#/definitions/v2.Error"
                     }
                 },
-                "message": {
-                    "type": "string"
-                },
-                "source": {
-                    "type": "string"
+                "meta": {
+                    "$ref": "#/definitions/v2.Meta"
                 }
             }
         },
-        "models.OtherEpisode": {
+        "v2.Episode": {
             "type": "object",
             "properties": {
-                "release_date": {
+                "cover_url": {
                     "type": "string"
                 },
-                "thumbnail_url": {
+                "downloads": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.Download"
+                    }
+                },
+                "drama": {
+                    "$ref": "#/definitions/v2.EpisodeDrama"
+                },
+                "navigation": {
+                    "$ref": "#/definitions/v2.Navigation"
+                },
+                "other_episodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.EpisodeRef"
+                    }
+                },
+                "release_info": {
                     "type": "string"
                 },
+                "servers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.Server"
+                    }
+                },
+                "subtitles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StreamSubtitle"
+                    }
+                },
                 "title": {
                     "type": "string"
                 },
-                "url": {
-                    "type": "string"
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ExtractionWarning"
+                    }
                 }
             }
         },
-        "models.RatingObject": {
+        "v2.EpisodeDrama": {
             "type": "object",
             "properties": {
-                "score": {
+                "cover_url": {
                     "type": "string"
                 },
-                "users": {
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "synopsis": {
+                    "type": "string"
+                },
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "models.RecommendationItem": {
+        "v2.EpisodeRef": {
             "type": "object",
             "properties": {
-                "anime_slug": {
+                "cover_url": {
                     "type": "string"
                 },
-                "cover_url": {
+                "number": {
                     "type": "string"
                 },
-                "episode": {
+                "release_date": {
                     "type": "string"
                 },
-                "rating": {
+                "slug": {
                     "type": "string"
                 },
                 "title": {
@@ -930,31 +3593,33 @@ const docTemplate = `{
                 }
             }
         },
-        "models.ReleaseEntry": {
+        "v2.Error": {
             "type": "object",
             "properties": {
-                "anime_slug": {
+                "code": {
                     "type": "string"
                 },
-                "cover_url": {
+                "message": {
                     "type": "string"
                 },
-                "genres": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "release_time": {
+                "param": {
                     "type": "string"
                 },
-                "score": {
+                "request_id": {
                     "type": "string"
+                }
+            }
+        },
+        "v2.Genre": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
                 },
-                "title": {
+                "name": {
                     "type": "string"
                 },
-                "type": {
+                "slug": {
                     "type": "string"
                 },
                 "url": {
@@ -962,171 +3627,178 @@ const docTemplate = `{
                 }
             }
         },
-        "models.ReleaseScheduleResponse": {
+        "v2.Home": {
             "type": "object",
             "properties": {
-                "confidence_score": {
-                    "type": "number"
+                "latest_episodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.Drama"
+                    }
                 },
-                "data": {
+                "movies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.Drama"
+                    }
+                },
+                "schedule": {
                     "type": "object",
                     "additionalProperties": {
                         "type": "array",
                         "items": {
-                            "$ref": "#/definitions/models.ReleaseEntry"
+                            "$ref": "#/definitions/v2.ScheduleEntry"
                         }
                     }
                 },
-                "message": {
-                    "type": "string"
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SectionStatus"
+                    }
                 },
-                "source": {
-                    "type": "string"
+                "top": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v2.Drama"
+                    }
                 }
             }
         },
-        "models.ScheduleByDayResponse": {
+        "v2.Meta": {
             "type": "object",
             "properties": {
-                "confidence_score": {
-                    "type": "number"
+                "confidence": {
+                    "$ref": "#/definitions/v2.Confidence"
                 },
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.ScheduleEntry"
-                    }
-                },
-                "message": {
-                    "type": "string"
+                "pagination": {
+                    "$ref": "#/definitions/v2.Pagination"
                 },
                 "source": {
                     "type": "string"
                 }
             }
         },
-        "models.ScheduleEntry": {
+        "v2.Navigation": {
             "type": "object",
             "properties": {
-                "anime_slug": {
+                "all_episodes_url": {
                     "type": "string"
                 },
-                "cover_url": {
+                "next_url": {
                     "type": "string"
                 },
-                "genres": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "release_time": {
+                "previous_url": {
                     "type": "string"
+                }
+            }
+        },
+        "v2.Pagination": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
                 },
-                "score": {
-                    "type": "string"
+                "next_page": {
+                    "type": "integer"
                 },
-                "title": {
-                    "type": "string"
+                "page": {
+                    "type": "integer"
                 },
-                "type": {
+                "prev_page": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.Rating": {
+            "type": "object",
+            "properties": {
+                "score": {
                     "type": "string"
                 },
-                "url": {
+                "users": {
                     "type": "string"
                 }
             }
         },
-        "models.SearchDetail": {
+        "v2.ScheduleEntry": {
             "type": "object",
             "properties": {
-                "anime_slug": {
+                "cover_url": {
                     "type": "string"
                 },
-                "cover": {
+                "day": {
                     "type": "string"
                 },
-                "genre": {
+                "genres": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "judul": {
+                "latest_episode": {
                     "type": "string"
                 },
-                "penonton": {
+                "next_air": {
+                    "description": "NextAir is the next broadcast as ISO-8601, only on the schedule endpoints",
                     "type": "string"
                 },
-                "sinopsis": {
+                "release_time": {
                     "type": "string"
                 },
-                "skor": {
+                "released": {
                     "type": "string"
                 },
-                "status": {
+                "score": {
                     "type": "string"
                 },
-                "tipe": {
+                "slug": {
                     "type": "string"
                 },
-                "url": {
+                "status": {
                     "type": "string"
-                }
-            }
-        },
-        "models.SearchResponse": {
-            "type": "object",
-            "properties": {
-                "confidence_score": {
-                    "type": "number"
                 },
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.SearchDetail"
-                    }
+                "synopsis": {
+                    "type": "string"
                 },
-                "message": {
+                "title": {
                     "type": "string"
                 },
-                "source": {
+                "type": {
                     "type": "string"
-                }
-            }
-        },
-        "models.StreamingServer": {
-            "type": "object",
-            "properties": {
-                "server_name": {
+                },
+                "url": {
                     "type": "string"
                 },
-                "streaming_url": {
+                "views": {
                     "type": "string"
                 }
             }
         },
-        "models.Top10Item": {
+        "v2.Server": {
             "type": "object",
             "properties": {
-                "anime_slug": {
+                "health": {
+                    "$ref": "#/definitions/models.ServerHealth"
+                },
+                "id": {
                     "type": "string"
                 },
-                "cover": {
+                "label": {
                     "type": "string"
                 },
-                "genres": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "name": {
+                    "type": "string"
                 },
-                "judul": {
+                "quality": {
                     "type": "string"
                 },
-                "rating": {
+                "resolve_error": {
                     "type": "string"
                 },
+                "resolved": {
+                    "$ref": "#/definitions/models.ResolvedStream"
+                },
                 "url": {
                     "type": "string"
                 }
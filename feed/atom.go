@@ -0,0 +1,90 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+type atomDocument struct {
+	XMLName  xml.Name    `xml:"feed"`
+	XMLNS    string      `xml:"xmlns,attr"`
+	Lang     string      `xml:"xml:lang,attr,omitempty"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Author   atomPerson  `xml:"author"`
+	Logo     string      `xml:"logo,omitempty"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Updated   string     `xml:"updated"`
+	Published string     `xml:"published,omitempty"`
+	Links     []atomLink `xml:"link"`
+	Summary   string     `xml:"summary,omitempty"`
+}
+
+// Atom encodes the feed as Atom 1.0
+func Atom(f *Feed) ([]byte, error) {
+	updated := f.Updated()
+	if updated.IsZero() {
+		// updated wajib ada di Atom; feed kosong memakai waktu epoch agar tetap stabil
+		updated = time.Unix(0, 0)
+	}
+
+	document := atomDocument{
+		XMLNS:    atomNamespace,
+		Lang:     f.Language,
+		ID:       f.ID,
+		Title:    f.Title,
+		Subtitle: f.Description,
+		Updated:  updated.UTC().Format(time.RFC3339),
+		Links:    []atomLink{{Href: f.Link, Rel: "alternate", Type: "text/html"}},
+		Author:   atomPerson{Name: f.Author},
+		Logo:     f.Image,
+		Entries:  make([]atomEntry, 0, len(f.Items)),
+	}
+	if document.Author.Name == "" {
+		document.Author.Name = f.Title
+	}
+	if f.SelfURL != "" {
+		document.Links = append(document.Links, atomLink{Href: f.SelfURL, Rel: "self", Type: "application/atom+xml"})
+	}
+
+	for _, item := range f.Items {
+		// updated wajib ada; published yang tidak diketahui tidak dikirim
+		entry := atomEntry{
+			ID:      item.GUID,
+			Title:   item.Title,
+			Updated: updated.UTC().Format(time.RFC3339),
+			Links:   []atomLink{{Href: item.Link, Rel: "alternate", Type: "text/html"}},
+			Summary: item.Description,
+		}
+		if !item.Published.IsZero() {
+			entry.Updated = item.Published.UTC().Format(time.RFC3339)
+			entry.Published = entry.Updated
+		}
+		if item.Image != "" {
+			entry.Links = append(entry.Links, atomLink{Href: item.Image, Rel: "enclosure", Type: imageType(item.Image)})
+		}
+		document.Entries = append(document.Entries, entry)
+	}
+
+	return marshal(document)
+}
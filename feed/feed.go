@@ -0,0 +1,71 @@
+// Package feed menyusun feed RSS 2.0 dan Atom 1.0 dari data drama, sehingga episode
+// baru bisa diikuti lewat feed reader atau bot.
+package feed
+
+import (
+	"encoding/xml"
+	"mime"
+	"path"
+	"time"
+)
+
+// Feed is a format independent feed
+type Feed struct {
+	// ID is the stable identifier of the feed (Atom id)
+	ID          string
+	Title       string
+	Link        string
+	SelfURL     string
+	Description string
+	Language    string
+	Image       string
+	// Author is required by Atom; Title is used when empty
+	Author string
+	Items  []Item
+}
+
+// Item is one entry of a feed
+type Item struct {
+	// GUID is stable per episode, so readers never show the same episode twice
+	GUID        string
+	Title       string
+	Link        string
+	Description string
+	Published   time.Time
+	// Image is sent as an enclosure (RSS) or enclosure link (Atom)
+	Image string
+}
+
+// Updated returns the newest publish time of the items, zero if there are none
+func (f *Feed) Updated() time.Time {
+	var updated time.Time
+	for _, item := range f.Items {
+		if item.Published.After(updated) {
+			updated = item.Published
+		}
+	}
+	return updated
+}
+
+// Content types of the encoded feeds
+const (
+	RSSContentType  = "application/rss+xml; charset=utf-8"
+	AtomContentType = "application/atom+xml; charset=utf-8"
+)
+
+// imageType guesses the media type of an image URL for enclosures
+func imageType(imageURL string) string {
+	if t := mime.TypeByExtension(path.Ext(imageURL)); t != "" {
+		return t
+	}
+	return "image/jpeg"
+}
+
+// marshal encodes v as an XML document with declaration
+func marshal(v interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,127 @@
+package feed
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testFeed() *Feed {
+	wib := time.FixedZone("WIB", 7*60*60)
+	return &Feed{
+		ID:          "https://dramaqu.ad/judul-drama/",
+		Title:       "DramaQu - Judul Drama",
+		Link:        "https://dramaqu.ad/judul-drama/",
+		SelfURL:     "http://localhost:8080/feeds/drama/judul-drama.xml",
+		Description: "Sinopsis & cerita",
+		Language:    "id",
+		Items: []Item{
+			{
+				GUID:      "https://dramaqu.ad/judul-drama/2/",
+				Title:     "Judul Drama - Episode 2",
+				Link:      "https://dramaqu.ad/judul-drama/2/",
+				Published: time.Date(2026, 10, 19, 20, 0, 0, 0, wib),
+				Image:     "https://dramaqu.ad/wp-content/uploads/cover.png",
+			},
+			{
+				GUID:      "https://dramaqu.ad/judul-drama/",
+				Title:     "Judul Drama - Episode 1",
+				Link:      "https://dramaqu.ad/judul-drama/",
+				Published: time.Date(2026, 10, 12, 20, 0, 0, 0, wib),
+			},
+		},
+	}
+}
+
+func TestRSS(t *testing.T) {
+	body, err := RSS(testFeed())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc rssDocument
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, body)
+	}
+	if doc.Version != "2.0" || doc.Channel.Description != "Sinopsis & cerita" {
+		t.Errorf("channel = %+v", doc.Channel)
+	}
+	if doc.Channel.LastBuildDate != "Mon, 19 Oct 2026 20:00:00 +0700" {
+		t.Errorf("lastBuildDate = %q, want the newest item", doc.Channel.LastBuildDate)
+	}
+	if len(doc.Channel.Items) != 2 {
+		t.Fatalf("got %d items", len(doc.Channel.Items))
+	}
+	first := doc.Channel.Items[0]
+	if first.GUID.Value != "https://dramaqu.ad/judul-drama/2/" || !first.GUID.IsPermaLink {
+		t.Errorf("guid = %+v", first.GUID)
+	}
+	if first.Enclosure == nil || first.Enclosure.Type != "image/png" || first.Enclosure.URL != "https://dramaqu.ad/wp-content/uploads/cover.png" {
+		t.Errorf("enclosure = %+v", first.Enclosure)
+	}
+	if doc.Channel.Items[1].Enclosure != nil {
+		t.Errorf("item without image has enclosure %+v", doc.Channel.Items[1].Enclosure)
+	}
+	if !strings.Contains(string(body), `<atom:link href="http://localhost:8080/feeds/drama/judul-drama.xml" rel="self" type="application/rss+xml">`) {
+		t.Errorf("missing self link:\n%s", body)
+	}
+}
+
+func TestAtom(t *testing.T) {
+	body, err := Atom(testFeed())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc atomDocument
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, body)
+	}
+	if doc.ID != "https://dramaqu.ad/judul-drama/" || doc.Updated != "2026-10-19T13:00:00Z" {
+		t.Errorf("feed id %q updated %q", doc.ID, doc.Updated)
+	}
+	if len(doc.Entries) != 2 {
+		t.Fatalf("got %d entries", len(doc.Entries))
+	}
+	entry := doc.Entries[0]
+	if entry.ID != "https://dramaqu.ad/judul-drama/2/" || entry.Published != "2026-10-19T13:00:00Z" {
+		t.Errorf("entry = %+v", entry)
+	}
+	if len(entry.Links) != 2 || entry.Links[1].Rel != "enclosure" || entry.Links[1].Type != "image/png" {
+		t.Errorf("entry links = %+v", entry.Links)
+	}
+
+	// Feed kosong tetap valid dan stabil
+	empty, err := Atom(&Feed{ID: "https://dramaqu.ad/", Title: "Kosong", Link: "https://dramaqu.ad/"})
+	if err != nil || !strings.Contains(string(empty), "<updated>1970-01-01T00:00:00Z</updated>") {
+		t.Errorf("empty feed: %v\n%s", err, empty)
+	}
+}
+
+func TestUnknownPublishDateIsOmitted(t *testing.T) {
+	f := testFeed()
+	f.Items[1].Published = time.Time{}
+
+	rss, err := RSS(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(rss), "<pubDate>"); got != 1 {
+		t.Errorf("got %d pubDate, want only the item with a known date:\n%s", got, rss)
+	}
+
+	body, err := Atom(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc atomDocument
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, body)
+	}
+	entry := doc.Entries[1]
+	// updated wajib di Atom; memakai waktu update feed, published dihilangkan
+	if entry.Published != "" || entry.Updated != "2026-10-19T13:00:00Z" {
+		t.Errorf("entry published %q updated %q", entry.Published, entry.Updated)
+	}
+}
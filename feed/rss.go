@@ -0,0 +1,90 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	SelfLink      *atomLink `xml:"atom:link,omitempty"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language,omitempty"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Image         *rssImage `xml:"image,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssImage struct {
+	URL   string `xml:"url"`
+	Title string `xml:"title"`
+	Link  string `xml:"link"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	Description string        `xml:"description,omitempty"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate,omitempty"`
+	Enclosure   *rssEnclosure `xml:"enclosure,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int    `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// RSS encodes the feed as RSS 2.0
+func RSS(f *Feed) ([]byte, error) {
+	channel := rssChannel{
+		Title:       f.Title,
+		Link:        f.Link,
+		Description: f.Description,
+		Language:    f.Language,
+		Items:       make([]rssItem, 0, len(f.Items)),
+	}
+	if f.SelfURL != "" {
+		channel.SelfLink = &atomLink{Href: f.SelfURL, Rel: "self", Type: "application/rss+xml"}
+	}
+	if updated := f.Updated(); !updated.IsZero() {
+		channel.LastBuildDate = updated.Format(time.RFC1123Z)
+	}
+	if f.Image != "" {
+		channel.Image = &rssImage{URL: f.Image, Title: f.Title, Link: f.Link}
+	}
+
+	for _, item := range f.Items {
+		entry := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			Description: item.Description,
+			// GUID berupa URL episode sehingga juga bisa dipakai sebagai permalink
+			GUID: rssGUID{IsPermaLink: item.GUID == item.Link, Value: item.GUID},
+		}
+		if !item.Published.IsZero() {
+			entry.PubDate = item.Published.Format(time.RFC1123Z)
+		}
+		if item.Image != "" {
+			// Panjang file tidak diketahui; 0 lazim dipakai untuk enclosure seperti ini
+			entry.Enclosure = &rssEnclosure{URL: item.Image, Type: imageType(item.Image)}
+		}
+		channel.Items = append(channel.Items, entry)
+	}
+
+	return marshal(rssDocument{Version: "2.0", Atom: atomNamespace, Channel: channel})
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/nabilulilalbab/dramaqu/apierror"
 	"github.com/nabilulilalbab/dramaqu/services"
 )
 
@@ -25,31 +26,27 @@ func NewAnimeTerbaruHandler(service *services.AnimeTerbaruService) *AnimeTerbaru
 // @Description Mengambil daftar anime terbaru dengan pagination
 // @Tags anime-terbaru
 // @Accept json
-// @Produce json
+// @Produce json,text/csv,application/x-ndjson,application/msgpack
 // @Param page query int false "Nomor halaman" default(1)
+// @Param format query string false "Format output (default JSON, juga lewat header Accept)" Enums(json, csv, ndjson, msgpack)
 // @Success 200 {object} models.OngoingDramaResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} apierror.Response
+// @Failure 502 {object} apierror.Response
+// @Failure 504 {object} apierror.Response
 // @Router /api/v1/anime-terbaru [get]
 func (h *AnimeTerbaruHandler) GetAnimeTerbaru(c *gin.Context) {
 	// Get page parameter from query, default to 1
 	pageStr := c.DefaultQuery("page", "1")
 	page, err := strconv.Atoi(pageStr)
 	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid page parameter",
-			"message": "Page must be a positive integer",
-		})
+		abort(c, apierror.InvalidParam("page", msgPage, nil))
 		return
 	}
 
 	// Get anime terbaru data from service
-	data, err := h.service.GetAnimeTerbaru(page)
+	data, err := h.service.GetAnimeTerbaru(c.Request.Context(), page)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to fetch anime terbaru data",
-			"message": err.Error(),
-		})
+		abort(c, upstreamError(err))
 		return
 	}
 
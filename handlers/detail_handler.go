@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/nabilulilalbab/dramaqu/apierror"
 	"github.com/nabilulilalbab/dramaqu/services"
 )
 
@@ -24,29 +25,29 @@ func NewDetailHandler(service *services.DetailService) *DetailHandler {
 // @Produce json
 // @Param anime_slug query string true "Anime/Movie/Series slug (contoh: 'kobane-2022', 'film/kobane-2022', 'series/legend-of-the-female-general')"
 // @Success 200 {object} models.DetailResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} apierror.Response
+// @Failure 404 {object} apierror.Response
+// @Failure 502 {object} apierror.Response
+// @Failure 504 {object} apierror.Response
 // @Router /api/v1/anime-detail [get]
 func (h *DetailHandler) GetAnimeDetail(c *gin.Context) {
 	// Get anime_slug parameter
 	animeSlug := c.Query("anime_slug")
 	if strings.TrimSpace(animeSlug) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Anime slug parameter is required",
-			"message": "Please provide an anime_slug parameter",
-		})
+		abort(c, apierror.InvalidParam("anime_slug", apierror.Message{
+			EN: "Please provide an anime_slug parameter",
+			ID: "Isi parameter anime_slug",
+		}, nil))
 		return
 	}
 
 	// Get detail data from service
-	data, err := h.service.GetDetailDrama(animeSlug)
+	data, err := h.service.GetDetailDrama(c.Request.Context(), animeSlug)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to fetch anime detail",
-			"message": err.Error(),
-		})
+		abort(c, upstreamError(err))
 		return
 	}
 
+	setLastModified(c, h.service.LastScraped(animeSlug))
 	c.JSON(http.StatusOK, data)
 }
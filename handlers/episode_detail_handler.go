@@ -2,18 +2,35 @@ package handlers
 
 import (
 	"net/http"
+	"regexp"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/nabilulilalbab/dramaqu/apierror"
+	"github.com/nabilulilalbab/dramaqu/models"
 	"github.com/nabilulilalbab/dramaqu/services"
+	"github.com/nabilulilalbab/dramaqu/urlguard"
 )
 
+// episodePathPattern matches the path of a drama page or one of its episodes
+var episodePathPattern = regexp.MustCompile(`^/[a-z0-9]+(?:-[a-z0-9]+)*(?:/[0-9]+)?/?$`)
+
 type EpisodeDetailHandler struct {
-	service *services.EpisodeDetailService
+	service       *services.EpisodeDetailService
+	streamService *services.StreamService
+	healthService *services.HealthCheckService
+	validator     *urlguard.Validator
+	urlPolicy     urlguard.Policy
 }
 
-func NewEpisodeDetailHandler(service *services.EpisodeDetailService) *EpisodeDetailHandler {
-	return &EpisodeDetailHandler{service: service}
+func NewEpisodeDetailHandler(service *services.EpisodeDetailService, streamService *services.StreamService, healthService *services.HealthCheckService, validator *urlguard.Validator) *EpisodeDetailHandler {
+	return &EpisodeDetailHandler{
+		service:       service,
+		streamService: streamService,
+		healthService: healthService,
+		validator:     validator,
+		urlPolicy:     urlguard.Policy{AllowHost: service.AllowsHost, Path: episodePathPattern},
+	}
 }
 
 // GetEpisodeDetail handles GET /api/v1/episode-detail
@@ -23,30 +40,99 @@ func NewEpisodeDetailHandler(service *services.EpisodeDetailService) *EpisodeDet
 // @Accept json
 // @Produce json
 // @Param episode_url query string true "URL episode"
+// @Param resolve query bool false "Resolve setiap server streaming menjadi URL HLS/MP4 langsung dengan proxy_url bertanda tangan" default(false)
+// @Param only_alive query bool false "Hanya tampilkan server streaming yang lolos health check" default(false)
 // @Success 200 {object} models.EpisodeDetailResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} apierror.Response
+// @Failure 404 {object} apierror.Response
+// @Failure 502 {object} apierror.Response
+// @Failure 504 {object} apierror.Response
 // @Router /api/v1/episode-detail [get]
 func (h *EpisodeDetailHandler) GetEpisodeDetail(c *gin.Context) {
 	// Get episode_url parameter
 	episodeURL := c.Query("episode_url")
 	if strings.TrimSpace(episodeURL) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Episode URL parameter is required",
-			"message": "Please provide an episode_url parameter",
-		})
+		abort(c, apierror.InvalidParam("episode_url", apierror.Message{
+			EN: "Please provide an episode_url parameter",
+			ID: "Isi parameter episode_url",
+		}, nil))
+		return
+	}
+
+	// episode_url hanya boleh menunjuk ke halaman episode di host upstream
+	validURL, err := h.validator.Validate(c.Request.Context(), episodeURL, h.urlPolicy)
+	if err != nil {
+		abort(c, apierror.InvalidParam("episode_url", apierror.Message{
+			EN: "URL must point to an episode page on the source site",
+			ID: "URL harus menunjuk ke halaman episode di situs sumber",
+		}, err))
 		return
 	}
 
 	// Get episode detail data from service
-	data, err := h.service.GetEpisodeDetail(episodeURL)
+	data, err := h.service.GetEpisodeDetail(c.Request.Context(), validURL.String())
+	h.respond(c, data, err)
+}
+
+// GetEpisodeBySlug handles GET /api/v1/dramas/:slug/episodes/:n
+// @Summary Get episode detail by slug
+// @Description Mengambil detail episode berdasarkan slug drama dan nomor episode. URL episode dibangun dari BASE_URL; parameter n juga menerima format episode_slug ('{slug}-episode-{n}')
+// @Tags episode-detail
+// @Accept json
+// @Produce json
+// @Param slug path string true "Slug drama (contoh: 'love-in-the-air')"
+// @Param n path string true "Nomor episode atau episode_slug (contoh: '3' atau 'love-in-the-air-episode-3')"
+// @Param resolve query bool false "Resolve setiap server streaming menjadi URL HLS/MP4 langsung dengan proxy_url bertanda tangan" default(false)
+// @Param only_alive query bool false "Hanya tampilkan server streaming yang lolos health check" default(false)
+// @Success 200 {object} models.EpisodeDetailResponse
+// @Failure 400 {object} apierror.Response
+// @Failure 404 {object} apierror.Response
+// @Failure 502 {object} apierror.Response
+// @Failure 504 {object} apierror.Response
+// @Router /api/v1/dramas/{slug}/episodes/{n} [get]
+func (h *EpisodeDetailHandler) GetEpisodeBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+	if !slugPattern.MatchString(slug) {
+		abort(c, apierror.InvalidParam("slug", msgSlug, nil))
+		return
+	}
+
+	n, err := services.ParseEpisodeRef(slug, c.Param("n"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to fetch episode detail",
-			"message": err.Error(),
-		})
+		abort(c, apierror.InvalidParam("n", msgEpisodeRef, err))
 		return
 	}
 
+	data, err := h.service.GetEpisodeBySlug(c.Request.Context(), slug, n)
+	if err == nil {
+		setLastModified(c, h.service.LastScraped(slug))
+	}
+	h.respond(c, data, err)
+}
+
+// respond writes the v1 episode detail response
+func (h *EpisodeDetailHandler) respond(c *gin.Context, data *models.EpisodeDetailResponse, err error) {
+	if err != nil {
+		abort(c, upstreamError(err))
+		return
+	}
+
+	h.enrich(c, data)
 	c.JSON(http.StatusOK, data)
 }
+
+// enrich applies the optional health filter and stream resolving of the request
+func (h *EpisodeDetailHandler) enrich(c *gin.Context, data *models.EpisodeDetailResponse) {
+	// Buang server yang mati; server yang belum pernah dicek diperiksa sekarang
+	if c.Query("only_alive") == "true" {
+		h.healthService.CheckUnknown(c.Request.Context(), data)
+		h.healthService.FilterAlive(data)
+	}
+
+	// Resolve iframe player menjadi URL media langsung jika diminta. Link proxy
+	// bertanda tangan (dan bisa terikat IP client) sehingga tidak boleh di-cache bersama.
+	if c.Query("resolve") == "true" {
+		h.streamService.ResolveServers(c.Request.Context(), data, c.ClientIP())
+		c.Header("Cache-Control", "private, no-cache")
+	}
+}
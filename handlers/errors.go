@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nabilulilalbab/dramaqu/apierror"
+	"github.com/nabilulilalbab/dramaqu/services"
+)
+
+// Messages shared by several handlers
+var (
+	msgPage = apierror.Message{
+		EN: "Page must be a positive integer",
+		ID: "Page harus berupa bilangan bulat positif",
+	}
+	msgSlug = apierror.Message{
+		EN: "Slug may only contain lowercase letters, numbers and dashes",
+		ID: "Slug hanya boleh berisi huruf kecil, angka dan tanda hubung",
+	}
+	msgDay = apierror.Message{
+		EN: "Day must be one of: monday, tuesday, wednesday, thursday, friday, saturday, sunday (or senin, selasa, rabu, kamis, jumat, sabtu, minggu)",
+		ID: "Day harus salah satu dari: senin, selasa, rabu, kamis, jumat, sabtu, minggu (atau monday, tuesday, wednesday, thursday, friday, saturday, sunday)",
+	}
+	msgTimezone = apierror.Message{
+		EN: "Tz must be an IANA timezone name such as Asia/Jakarta, Asia/Makassar or Europe/London",
+		ID: "Tz harus berupa nama zona waktu IANA seperti Asia/Jakarta, Asia/Makassar atau Europe/London",
+	}
+	msgQuery = apierror.Message{
+		EN: "Please provide a search query in the q parameter",
+		ID: "Isi kata kunci pencarian pada parameter q",
+	}
+	msgSearchQuery = apierror.Message{
+		EN: "Please provide a search query in the query parameter",
+		ID: "Isi kata kunci pencarian pada parameter query",
+	}
+	msgLimit = apierror.Message{
+		EN: "Limit must be an integer between 1 and 20",
+		ID: "Limit harus berupa bilangan bulat antara 1 dan 20",
+	}
+	msgEpisodeRef = apierror.Message{
+		EN: "Episode must be a number or an episode slug of this drama",
+		ID: "Episode harus berupa nomor atau episode slug dari drama ini",
+	}
+	msgURLRequired = apierror.Message{
+		EN: "Please provide an url parameter",
+		ID: "Isi parameter url",
+	}
+	msgHostNotAllowed = apierror.Message{
+		EN: "Host is not allowed",
+		ID: "Host tidak diizinkan",
+	}
+)
+
+// upstreamError classifies an error returned by a scraping service
+func upstreamError(err error) *apierror.Error {
+	var upstream *services.UpstreamError
+	switch {
+	case errors.Is(err, context.Canceled):
+		return apierror.New(apierror.CodeTimeout, apierror.Message{}, err).WithStatus(apierror.StatusClientClosedRequest)
+	case errors.Is(err, context.DeadlineExceeded):
+		return apierror.New(apierror.CodeTimeout, apierror.Message{}, err)
+	case errors.Is(err, services.ErrNotFound):
+		return apierror.New(apierror.CodeNotFound, apierror.Message{}, err)
+	case errors.Is(err, services.ErrUpstreamChanged):
+		return apierror.New(apierror.CodeUpstreamChanged, apierror.Message{}, err)
+	case errors.As(err, &upstream) && upstream.StatusCode == http.StatusTooManyRequests:
+		return apierror.New(apierror.CodeRateLimited, apierror.Message{}, err)
+	}
+	return apierror.New(apierror.CodeUpstreamUnavailable, apierror.Message{}, err)
+}
+
+// abort records err for the RenderErrors middleware
+func abort(c *gin.Context, err *apierror.Error) {
+	c.Error(err)
+	c.Abort()
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nabilulilalbab/dramaqu/apierror"
+	"github.com/nabilulilalbab/dramaqu/feed"
+	"github.com/nabilulilalbab/dramaqu/services"
+)
+
+// Feed file extensions
+const (
+	feedExtRSS  = ".xml"
+	feedExtAtom = ".atom"
+)
+
+var msgFeedFormat = apierror.Message{
+	EN: "Feed must end with .xml (RSS) or .atom (Atom)",
+	ID: "Feed harus berakhiran .xml (RSS) atau .atom (Atom)",
+}
+
+// FeedHandler handles RSS/Atom feed requests
+type FeedHandler struct {
+	service   *services.FeedService
+	publicURL string
+}
+
+// NewFeedHandler creates a new FeedHandler. publicURL adalah root publik API
+// (PUBLIC_URL) untuk link self; kosong berarti feed dikirim tanpa link self.
+func NewFeedHandler(service *services.FeedService, publicURL string) *FeedHandler {
+	return &FeedHandler{
+		service:   service,
+		publicURL: publicURL,
+	}
+}
+
+// GetOngoing handles GET /feeds/ongoing.xml and /feeds/ongoing.atom
+// @Summary Feed episode terbaru
+// @Description Feed RSS 2.0 (.xml) atau Atom (.atom) berisi episode terbaru setiap drama ongoing. GUID item adalah URL episode, pubDate hanya dikirim jika tanggal rilis diketahui dari halaman sumber, cover dikirim sebagai enclosure. Mendukung ETag dan Last-Modified
+// @Tags feeds
+// @Produce application/rss+xml,application/atom+xml
+// @Success 200 {string} string "Dokumen RSS atau Atom"
+// @Success 304 "Feed tidak berubah"
+// @Failure 502 {object} apierror.Response
+// @Failure 504 {object} apierror.Response
+// @Router /feeds/ongoing.xml [get]
+// @Router /feeds/ongoing.atom [get]
+func (h *FeedHandler) GetOngoing(c *gin.Context) {
+	ext := feedExtRSS
+	if strings.HasSuffix(c.Request.URL.Path, feedExtAtom) {
+		ext = feedExtAtom
+	}
+
+	result, err := h.service.Ongoing(c.Request.Context())
+	if err != nil {
+		abort(c, upstreamError(err))
+		return
+	}
+	h.render(c, result, ext)
+}
+
+// GetDrama handles GET /feeds/drama/{slug}.xml and /feeds/drama/{slug}.atom
+// @Summary Feed episode drama
+// @Description Feed RSS 2.0 ({slug}.xml) atau Atom ({slug}.atom) berisi semua episode satu drama, terbaru lebih dulu. Mendukung ETag dan Last-Modified
+// @Tags feeds
+// @Produce application/rss+xml,application/atom+xml
+// @Param file path string true "Slug drama dengan ekstensi feed, contoh judul-drama.xml atau judul-drama.atom"
+// @Success 200 {string} string "Dokumen RSS atau Atom"
+// @Success 304 "Feed tidak berubah"
+// @Failure 400 {object} apierror.Response
+// @Failure 404 {object} apierror.Response
+// @Failure 502 {object} apierror.Response
+// @Failure 504 {object} apierror.Response
+// @Router /feeds/drama/{file} [get]
+func (h *FeedHandler) GetDrama(c *gin.Context) {
+	file := c.Param("file")
+	var slug, ext string
+	for _, candidate := range []string{feedExtRSS, feedExtAtom} {
+		if trimmed, ok := strings.CutSuffix(file, candidate); ok {
+			slug, ext = trimmed, candidate
+			break
+		}
+	}
+	if ext == "" {
+		abort(c, apierror.New(apierror.CodeNotFound, msgFeedFormat, nil))
+		return
+	}
+	if !slugPattern.MatchString(slug) {
+		abort(c, apierror.InvalidParam("slug", msgSlug, nil))
+		return
+	}
+
+	result, err := h.service.Drama(c.Request.Context(), slug)
+	if err != nil {
+		abort(c, upstreamError(err))
+		return
+	}
+	h.render(c, result, ext)
+}
+
+// render encodes the feed in the format of ext
+func (h *FeedHandler) render(c *gin.Context, result *feed.Feed, ext string) {
+	result.SelfURL = h.selfURL(c)
+
+	encode, contentType := feed.RSS, feed.RSSContentType
+	if ext == feedExtAtom {
+		encode, contentType = feed.Atom, feed.AtomContentType
+	}
+	body, err := encode(result)
+	if err != nil {
+		abort(c, apierror.From(err))
+		return
+	}
+
+	// Last-Modified dari item terbaru untuk If-Modified-Since (lihat middleware Cache)
+	setLastModified(c, result.Updated())
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// selfURL returns the public URL of the requested feed, tanpa query string.
+// Host diambil dari konfigurasi, bukan dari header Host/X-Forwarded-*, agar
+// feed yang di-cache tidak bisa diracuni dengan link self milik host lain.
+func (h *FeedHandler) selfURL(c *gin.Context) string {
+	if h.publicURL == "" {
+		return ""
+	}
+	return h.publicURL + c.Request.URL.Path
+}
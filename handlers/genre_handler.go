@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nabilulilalbab/dramaqu/apierror"
+	"github.com/nabilulilalbab/dramaqu/services"
+)
+
+// slugPattern matches valid category and post slugs on the source site
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// GenreHandler handles genre related requests
+type GenreHandler struct {
+	service *services.GenreService
+}
+
+// NewGenreHandler creates a new GenreHandler
+func NewGenreHandler(service *services.GenreService) *GenreHandler {
+	return &GenreHandler{
+		service: service,
+	}
+}
+
+// GetGenres handles GET /api/v1/genres
+// @Summary Get genres
+// @Description Mengambil daftar semua genre beserta jumlah drama di setiap genre
+// @Tags genres
+// @Accept json
+// @Produce json,text/csv,application/x-ndjson,application/msgpack
+// @Param format query string false "Format output (default JSON, juga lewat header Accept)" Enums(json, csv, ndjson, msgpack)
+// @Success 200 {object} models.GenreListResponse
+// @Failure 502 {object} apierror.Response
+// @Failure 504 {object} apierror.Response
+// @Router /api/v1/genres [get]
+func (h *GenreHandler) GetGenres(c *gin.Context) {
+	data, err := h.service.GetGenres(c.Request.Context())
+	if err != nil {
+		abort(c, upstreamError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, data)
+}
+
+// GetDramasByGenre handles GET /api/v1/genres/{slug}
+// @Summary Get dramas by genre
+// @Description Mengambil daftar drama pada genre tertentu dengan pagination
+// @Tags genres
+// @Accept json
+// @Produce json,text/csv,application/x-ndjson,application/msgpack
+// @Param slug path string true "Slug genre (contoh: 'action', 'romance')"
+// @Param page query int false "Nomor halaman" default(1)
+// @Param format query string false "Format output (default JSON, juga lewat header Accept)" Enums(json, csv, ndjson, msgpack)
+// @Success 200 {object} models.DramaListResponse
+// @Failure 400 {object} apierror.Response
+// @Failure 502 {object} apierror.Response
+// @Failure 504 {object} apierror.Response
+// @Router /api/v1/genres/{slug} [get]
+func (h *GenreHandler) GetDramasByGenre(c *gin.Context) {
+	slug := c.Param("slug")
+	if !slugPattern.MatchString(slug) {
+		abort(c, apierror.InvalidParam("slug", msgSlug, nil))
+		return
+	}
+
+	// Get page parameter from query, default to 1
+	pageStr := c.DefaultQuery("page", "1")
+	page, err := strconv.Atoi(pageStr)
+	if err != nil || page < 1 {
+		abort(c, apierror.InvalidParam("page", msgPage, nil))
+		return
+	}
+
+	data, err := h.service.GetDramasByGenre(c.Request.Context(), slug, page)
+	if err != nil {
+		abort(c, upstreamError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, data)
+}
@@ -26,15 +26,13 @@ func NewHomeHandler(homeService *services.HomeService) *HomeHandler {
 // @Accept json
 // @Produce json
 // @Success 200 {object} models.FinalResponse
-// @Failure 500 {object} map[string]interface{}
+// @Failure 502 {object} apierror.Response
+// @Failure 504 {object} apierror.Response
 // @Router /api/v1/home [get]
 func (h *HomeHandler) GetHome(c *gin.Context) {
-	data, err := h.homeService.GetHomeData()
+	data, err := h.homeService.GetHomeData(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to fetch home data",
-			"message": err.Error(),
-		})
+		abort(c, upstreamError(err))
 		return
 	}
 
@@ -2,96 +2,200 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/nabilulilalbab/dramaqu/apierror"
+	"github.com/nabilulilalbab/dramaqu/ical"
 	"github.com/nabilulilalbab/dramaqu/services"
 )
 
+// calendarExt is the path suffix of the iCalendar export
+const calendarExt = ".ics"
+
+// msgHours is the error message of an invalid hours parameter
+var msgHours = apierror.Message{
+	EN: "Hours must be an integer between 1 and 168",
+	ID: "Hours harus berupa bilangan bulat antara 1 dan 168",
+}
+
 // ScheduleHandler handles schedule related requests
 type ScheduleHandler struct {
-	service *services.ScheduleService
+	service  *services.ScheduleService
+	upcoming *services.UpcomingService
 }
 
 // NewScheduleHandler creates a new ScheduleHandler
-func NewScheduleHandler(service *services.ScheduleService) *ScheduleHandler {
+func NewScheduleHandler(service *services.ScheduleService, upcoming *services.UpcomingService) *ScheduleHandler {
 	return &ScheduleHandler{
-		service: service,
+		service:  service,
+		upcoming: upcoming,
 	}
 }
 
 // GetReleaseSchedule handles GET /api/v1/jadwal-rilis
 // @Summary Get jadwal rilis
-// @Description Mengambil jadwal rilis anime per hari
+// @Description Mengambil jadwal rilis anime per hari. Hari dan jam rilis adalah perkiraan (situs sumber tidak mencantumkan jadwal) yang diturunkan dari slug, sehingga sama di setiap request dan di semua endpoint jadwal; response ditandai estimated=true. Dengan tz, entry dipindahkan ke hari dan jam rilis di zona waktu tersebut; next_air adalah tayangan berikutnya dalam ISO-8601
 // @Tags jadwal-rilis
 // @Accept json
 // @Produce json
+// @Param tz query string false "Zona waktu IANA untuk hari, release_time dan next_air (default Asia/Jakarta)"
 // @Success 200 {object} models.ReleaseScheduleResponse
-// @Failure 500 {object} map[string]interface{}
+// @Failure 502 {object} apierror.Response
+// @Failure 504 {object} apierror.Response
 // @Router /api/v1/jadwal-rilis [get]
 func (h *ScheduleHandler) GetReleaseSchedule(c *gin.Context) {
+	loc, tzErr := parseTimezone(c)
+	if tzErr != nil {
+		abort(c, tzErr)
+		return
+	}
+
 	// Get release schedule data from service
-	data, err := h.service.GetReleaseSchedule()
+	data, err := h.service.GetReleaseSchedule(c.Request.Context(), loc)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to fetch release schedule data",
-			"message": err.Error(),
-		})
+		abort(c, upstreamError(err))
 		return
 	}
 
 	c.JSON(http.StatusOK, data)
 }
 
+// GetScheduleCalendar handles GET /api/v1/jadwal-rilis.ics
+// @Summary Export jadwal rilis ke iCalendar
+// @Description Jadwal rilis semua hari sebagai event mingguan berulang (RRULE WEEKLY) di zona Asia/Jakarta dengan VTIMEZONE, satu event per drama. URL dan cover ada di deskripsi event
+// @Tags jadwal-rilis
+// @Produce text/calendar
+// @Param slugs query string false "Hanya ekspor drama ini (slug dipisah koma)"
+// @Success 200 {string} string "Dokumen iCalendar"
+// @Failure 400 {object} apierror.Response
+// @Failure 502 {object} apierror.Response
+// @Failure 504 {object} apierror.Response
+// @Router /api/v1/jadwal-rilis.ics [get]
+func (h *ScheduleHandler) GetScheduleCalendar(c *gin.Context) {
+	h.calendar(c, "")
+}
+
 // GetScheduleByDay handles GET /api/v1/jadwal-rilis/{day}
 // @Summary Get jadwal rilis by day
-// @Description Mengambil jadwal rilis anime untuk hari tertentu
+// @Description Mengambil jadwal rilis anime untuk hari tertentu, dengan perkiraan hari dan jam yang sama seperti /jadwal-rilis (ditandai estimated=true). Dengan akhiran .ics (contoh /jadwal-rilis/monday.ics) jadwal dikirim sebagai iCalendar seperti /jadwal-rilis.ics
 // @Tags jadwal-rilis
 // @Accept json
-// @Produce json
-// @Param day path string true "Nama hari (monday, tuesday, wednesday, thursday, friday, saturday, sunday)"
+// @Produce json,text/csv,application/x-ndjson,application/msgpack,text/calendar
+// @Param day path string true "Nama hari dalam bahasa Inggris atau Indonesia (monday ... sunday, senin ... minggu)"
+// @Param tz query string false "Zona waktu IANA untuk hari, release_time dan next_air (default Asia/Jakarta)"
+// @Param format query string false "Format output (default JSON, juga lewat header Accept)" Enums(json, csv, ndjson, msgpack)
+// @Param slugs query string false "Khusus .ics: hanya ekspor drama ini (slug dipisah koma)"
 // @Success 200 {object} models.ScheduleByDayResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} apierror.Response
+// @Failure 502 {object} apierror.Response
+// @Failure 504 {object} apierror.Response
 // @Router /api/v1/jadwal-rilis/{day} [get]
+// @Router /api/v1/jadwal-rilis/{day}.ics [get]
 func (h *ScheduleHandler) GetScheduleByDay(c *gin.Context) {
-	// Get day parameter from URL path
-	day := c.Param("day")
-	if day == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Day parameter is required",
-			"message": "Please provide a valid day (monday, tuesday, wednesday, thursday, friday, saturday, sunday)",
-		})
+	// Get day parameter from URL path; akhiran .ics meminta format iCalendar
+	rawDay, isCalendar := strings.CutSuffix(c.Param("day"), calendarExt)
+
+	// Validate day parameter (nama hari Inggris atau Indonesia)
+	day, ok := services.ParseScheduleDay(rawDay)
+	if !ok {
+		abort(c, apierror.InvalidParam("day", msgDay, nil))
 		return
 	}
 
-	// Validate day parameter
-	validDays := []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
-	isValidDay := false
-	for _, validDay := range validDays {
-		if strings.EqualFold(day, validDay) {
-			isValidDay = true
-			break
-		}
+	if isCalendar {
+		h.calendar(c, day)
+		return
 	}
 
-	if !isValidDay {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid day parameter",
-			"message": "Day must be one of: monday, tuesday, wednesday, thursday, friday, saturday, sunday",
-		})
+	loc, tzErr := parseTimezone(c)
+	if tzErr != nil {
+		abort(c, tzErr)
 		return
 	}
 
 	// Get schedule data for specific day from service
-	data, err := h.service.GetScheduleByDay(day)
+	data, err := h.service.GetScheduleByDay(c.Request.Context(), day, loc)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to fetch schedule data for the day",
-			"message": err.Error(),
-		})
+		abort(c, upstreamError(err))
 		return
 	}
 
 	c.JSON(http.StatusOK, data)
 }
+
+// calendar renders the schedule of day ("" for all days) as iCalendar
+func (h *ScheduleHandler) calendar(c *gin.Context, day string) {
+	var slugs []string
+	if raw := c.Query("slugs"); raw != "" {
+		for _, slug := range strings.Split(raw, ",") {
+			slug = strings.TrimSpace(slug)
+			if !slugPattern.MatchString(slug) {
+				abort(c, apierror.InvalidParam("slugs", msgSlug, nil))
+				return
+			}
+			slugs = append(slugs, slug)
+		}
+	}
+
+	cal, err := h.service.Calendar(c.Request.Context(), day, slugs)
+	if err != nil {
+		abort(c, upstreamError(err))
+		return
+	}
+	c.Data(http.StatusOK, ical.ContentType, ical.Encode(cal))
+}
+
+// GetUpcoming handles GET /api/v1/schedule/upcoming
+// @Summary Get episode yang akan tayang
+// @Description Drama ongoing yang tayang dalam beberapa jam ke depan, paling cepat lebih dulu, dengan nomor episode berikutnya (episode terbaru + 1), jam tayang (ISO-8601) dan hitung mundur dalam detik sejak generated_at. Jam tayang adalah perkiraan dari jadwal rilis (situs sumber tidak mencantumkan jadwal), ditandai estimated=true; nilainya sama di setiap request
+// @Tags jadwal-rilis
+// @Accept json
+// @Produce json,text/csv,application/x-ndjson,application/msgpack
+// @Param hours query int false "Rentang waktu ke depan dalam jam (1-168, default: 24)"
+// @Param tz query string false "Zona waktu IANA untuk release_time dan air_time (default Asia/Jakarta)"
+// @Param format query string false "Format output (default JSON, juga lewat header Accept)" Enums(json, csv, ndjson, msgpack)
+// @Success 200 {object} models.UpcomingResponse
+// @Failure 400 {object} apierror.Response
+// @Failure 502 {object} apierror.Response
+// @Failure 504 {object} apierror.Response
+// @Router /api/v1/schedule/upcoming [get]
+func (h *ScheduleHandler) GetUpcoming(c *gin.Context) {
+	hours, err := strconv.Atoi(c.DefaultQuery("hours", "24"))
+	if err != nil || hours < 1 || hours > services.UpcomingMaxHours {
+		abort(c, apierror.InvalidParam("hours", msgHours, nil))
+		return
+	}
+	loc, tzErr := parseTimezone(c)
+	if tzErr != nil {
+		abort(c, tzErr)
+		return
+	}
+
+	data, err := h.upcoming.GetUpcoming(c.Request.Context(), hours, loc)
+	if err != nil {
+		abort(c, upstreamError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, data)
+}
+
+// parseTimezone parses the optional tz query parameter; nil berarti zona waktu situs (WIB)
+func parseTimezone(c *gin.Context) (*time.Location, *apierror.Error) {
+	tz := c.Query("tz")
+	if tz == "" {
+		return nil, nil
+	}
+	// "Local" adalah zona waktu server, bukan nama IANA
+	if tz == "Local" {
+		return nil, apierror.InvalidParam("tz", msgTimezone, nil)
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, apierror.InvalidParam("tz", msgTimezone, err)
+	}
+	return loc, nil
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/nabilulilalbab/dramaqu/apierror"
 	"github.com/nabilulilalbab/dramaqu/services"
 )
 
@@ -22,21 +23,20 @@ func NewSearchHandler(service *services.SearchService) *SearchHandler {
 // @Description Mencari anime berdasarkan judul
 // @Tags search
 // @Accept json
-// @Produce json
+// @Produce json,text/csv,application/x-ndjson,application/msgpack
 // @Param query query string true "Query pencarian"
 // @Param page query int false "Nomor halaman (default: 1)"
+// @Param format query string false "Format output (default JSON, juga lewat header Accept)" Enums(json, csv, ndjson, msgpack)
 // @Success 200 {object} models.SearchResponse
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Failure 400 {object} apierror.Response
+// @Failure 502 {object} apierror.Response
+// @Failure 504 {object} apierror.Response
 // @Router /api/v1/search [get]
 func (h *SearchHandler) SearchDrama(c *gin.Context) {
 	// Get query parameter
 	query := c.Query("query")
 	if strings.TrimSpace(query) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Query parameter is required",
-			"message": "Please provide a search query",
-		})
+		abort(c, apierror.InvalidParam("query", msgSearchQuery, nil))
 		return
 	}
 
@@ -44,22 +44,45 @@ func (h *SearchHandler) SearchDrama(c *gin.Context) {
 	pageStr := c.DefaultQuery("page", "1")
 	page, err := strconv.Atoi(pageStr)
 	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid page parameter",
-			"message": "Page must be a positive integer",
-		})
+		abort(c, apierror.InvalidParam("page", msgPage, nil))
 		return
 	}
 
 	// Get search results from service
-	data, err := h.service.SearchDrama(query, page)
+	data, err := h.service.SearchDrama(c.Request.Context(), query, page)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to fetch search results",
-			"message": err.Error(),
-		})
+		abort(c, upstreamError(err))
 		return
 	}
 
 	c.JSON(http.StatusOK, data)
 }
+
+// Suggest handles GET /api/v1/search/suggest
+// @Summary Search suggestions
+// @Description Memberikan saran judul secara cepat dari data yang sudah pernah di-scrape (tanpa scraping ulang)
+// @Tags search
+// @Accept json
+// @Produce json,text/csv,application/x-ndjson,application/msgpack
+// @Param q query string true "Awalan judul yang sedang diketik"
+// @Param limit query int false "Jumlah saran maksimal (1-20, default: 8)"
+// @Param format query string false "Format output (default JSON, juga lewat header Accept)" Enums(json, csv, ndjson, msgpack)
+// @Success 200 {object} models.SuggestResponse
+// @Failure 400 {object} apierror.Response
+// @Router /api/v1/search/suggest [get]
+func (h *SearchHandler) Suggest(c *gin.Context) {
+	query := c.Query("q")
+	if strings.TrimSpace(query) == "" {
+		abort(c, apierror.InvalidParam("q", msgQuery, nil))
+		return
+	}
+
+	limitStr := c.DefaultQuery("limit", "8")
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 || limit > 20 {
+		abort(c, apierror.InvalidParam("limit", msgLimit, nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.service.Suggest(query, limit))
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nabilulilalbab/dramaqu/apierror"
+	"github.com/nabilulilalbab/dramaqu/resolver"
+	"github.com/nabilulilalbab/dramaqu/services"
+	"github.com/nabilulilalbab/dramaqu/urlguard"
+)
+
+// StreamHandler handles stream resolving requests
+type StreamHandler struct {
+	service   *services.StreamService
+	validator *urlguard.Validator
+	urlPolicy urlguard.Policy
+}
+
+// NewStreamHandler creates a new StreamHandler
+func NewStreamHandler(service *services.StreamService, validator *urlguard.Validator) *StreamHandler {
+	return &StreamHandler{
+		service:   service,
+		validator: validator,
+		urlPolicy: urlguard.Policy{AllowHost: service.Supports},
+	}
+}
+
+// Resolve handles GET /api/v1/stream/resolve
+// @Summary Resolve embed player
+// @Description Mengubah URL iframe player (embed) menjadi URL HLS/MP4 langsung beserta subtitle dan header yang dibutuhkan
+// @Tags stream
+// @Accept json
+// @Produce json
+// @Param url query string true "URL embed player (contoh: streaming_url dari episode-detail)"
+// @Success 200 {object} models.StreamResolveResponse
+// @Failure 400 {object} apierror.Response
+// @Failure 502 {object} apierror.Response
+// @Failure 504 {object} apierror.Response
+// @Router /api/v1/stream/resolve [get]
+func (h *StreamHandler) Resolve(c *gin.Context) {
+	embedURL := strings.TrimSpace(c.Query("url"))
+	if embedURL == "" {
+		abort(c, apierror.InvalidParam("url", msgURLRequired, nil))
+		return
+	}
+
+	// Hanya URL embed dari host yang punya resolver yang boleh di-fetch
+	validURL, err := h.validator.Validate(c.Request.Context(), embedURL, h.urlPolicy)
+	if err != nil {
+		abort(c, apierror.InvalidParam("url", apierror.Message{
+			EN: "URL must be an embed player on a supported host",
+			ID: "URL harus berupa embed player dari host yang didukung",
+		}, err))
+		return
+	}
+
+	data, err := h.service.Resolve(c.Request.Context(), validURL.String(), c.ClientIP())
+	if err != nil {
+		if errors.Is(err, resolver.ErrUnsupportedHost) {
+			abort(c, apierror.InvalidParam("url", apierror.Message{
+				EN: "This embed host is not supported",
+				ID: "Host embed ini belum didukung",
+			}, err))
+			return
+		}
+		abort(c, upstreamError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, data)
+}
+
+// Proxy handles GET /api/v1/stream/proxy
+// @Summary Proxy HLS stream
+// @Description Meneruskan playlist m3u8, segmen, key atau subtitle dari host hasil resolver dengan header yang dibutuhkan (Referer/Origin). URI di dalam playlist di-rewrite agar melewati proxy, dan segmen di-stream tanpa di-buffer penuh.
+// @Tags stream
+// @Produce application/vnd.apple.mpegurl
+// @Produce octet-stream
+// @Param url query string true "URL media hasil resolver (proxy_url dari stream/resolve)"
+// @Param expires query int true "Unix timestamp kedaluwarsa link"
+// @Param sig query string true "Tanda tangan HMAC link"
+// @Success 200 {file} file
+// @Success 206 {file} file
+// @Failure 400 {object} apierror.Response
+// @Failure 403 {object} apierror.Response
+// @Failure 502 {object} apierror.Response
+// @Router /api/v1/stream/proxy [get]
+func (h *StreamHandler) Proxy(c *gin.Context) {
+	target := strings.TrimSpace(c.Query("url"))
+	if target == "" {
+		abort(c, apierror.InvalidParam("url", msgURLRequired, nil))
+		return
+	}
+
+	resp, err := h.service.Proxy(c.Request.Context(), target, c.GetHeader("Range"), c.ClientIP())
+	if err != nil {
+		// URL yang salah bentuk atau bukan hasil resolver ditolak sebelum upstream dihubungi
+		if errors.Is(err, services.ErrInvalidProxyURL) || errors.Is(err, services.ErrProxyHostNotAllowed) {
+			abort(c, apierror.InvalidParam("url", apierror.Message{
+				EN: "URL must be a media URL returned by stream/resolve",
+				ID: "URL harus berupa URL media hasil stream/resolve",
+			}, err))
+			return
+		}
+		abort(c, upstreamError(err))
+		return
+	}
+
+	// Player di browser memuat stream lintas origin
+	c.Header("Access-Control-Allow-Origin", "*")
+	for key := range resp.Header {
+		c.Header(key, resp.Header.Get(key))
+	}
+
+	if resp.Playlist != nil {
+		c.Data(resp.StatusCode, resp.ContentType, resp.Playlist)
+		return
+	}
+
+	defer resp.Body.Close()
+	c.DataFromReader(resp.StatusCode, resp.ContentLength, resp.ContentType, resp.Body, nil)
+}
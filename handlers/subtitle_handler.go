@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nabilulilalbab/dramaqu/apierror"
+	"github.com/nabilulilalbab/dramaqu/services"
+	"github.com/nabilulilalbab/dramaqu/subtitle"
+	"github.com/nabilulilalbab/dramaqu/urlguard"
+)
+
+// maxSubtitleOffset limits the timing offset accepted by the converter
+const maxSubtitleOffset = 24 * time.Hour
+
+// maxSubtitleUpload limits the size of subtitles posted to the converter
+const maxSubtitleUpload = 2 << 20
+
+// msgSubtitleFormat is the message of an invalid to or from parameter
+var msgSubtitleFormat = apierror.Message{
+	EN: "Format must be one of: vtt, srt, ass",
+	ID: "Format harus salah satu dari: vtt, srt, ass",
+}
+
+// SubtitleHandler handles subtitle conversion requests
+type SubtitleHandler struct {
+	service   *services.SubtitleService
+	validator *urlguard.Validator
+	urlPolicy urlguard.Policy
+}
+
+// NewSubtitleHandler creates a new SubtitleHandler
+func NewSubtitleHandler(service *services.SubtitleService, validator *urlguard.Validator) *SubtitleHandler {
+	return &SubtitleHandler{
+		service:   service,
+		validator: validator,
+		urlPolicy: urlguard.Policy{AllowHost: service.AllowsHost},
+	}
+}
+
+// Convert handles GET and POST /api/v1/subtitles/convert
+// @Summary Convert subtitle
+// @Description Mengonversi subtitle SRT/ASS menjadi WebVTT (atau sebaliknya) dengan offset waktu opsional. GET mengambil subtitle dari URL hasil resolver, POST menerima isi file pada body.
+// @Tags subtitles
+// @Accept plain
+// @Produce plain
+// @Param url query string false "URL subtitle hasil resolver (wajib untuk GET)"
+// @Param to query string false "Format tujuan: vtt, srt atau ass" default(vtt)
+// @Param from query string false "Format asal: srt, vtt atau ass (default: deteksi otomatis)"
+// @Param offset query string false "Geser waktu dalam detik (contoh 2.5 atau -1) atau durasi Go (contoh 1500ms)"
+// @Success 200 {string} string "Isi subtitle hasil konversi"
+// @Failure 400 {object} apierror.Response
+// @Failure 403 {object} apierror.Response
+// @Failure 502 {object} apierror.Response
+// @Failure 504 {object} apierror.Response
+// @Router /api/v1/subtitles/convert [get]
+// @Router /api/v1/subtitles/convert [post]
+func (h *SubtitleHandler) Convert(c *gin.Context) {
+	to, err := subtitle.NormalizeFormat(c.DefaultQuery("to", subtitle.FormatVTT))
+	if err != nil {
+		abort(c, apierror.InvalidParam("to", msgSubtitleFormat, nil))
+		return
+	}
+
+	from := c.Query("from")
+	if from != "" {
+		if from, err = subtitle.NormalizeFormat(from); err != nil {
+			abort(c, apierror.InvalidParam("from", msgSubtitleFormat, nil))
+			return
+		}
+	}
+
+	offset, err := parseSubtitleOffset(c.Query("offset"))
+	if err != nil {
+		abort(c, apierror.InvalidParam("offset", apierror.Message{
+			EN: "Offset must be seconds (2.5, -1) or a duration (1500ms) within 24 hours",
+			ID: "Offset harus berupa detik (2.5, -1) atau durasi (1500ms) maksimal 24 jam",
+		}, err))
+		return
+	}
+
+	var data string
+	if c.Request.Method == http.MethodPost {
+		body, readErr := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxSubtitleUpload))
+		if readErr != nil {
+			abort(c, apierror.InvalidParam("body", apierror.Message{
+				EN: "Subtitle body must not exceed 2 MB",
+				ID: "Isi subtitle maksimal 2 MB",
+			}, readErr))
+			return
+		}
+		data, err = h.service.Convert(string(body), from, to, offset)
+	} else {
+		subtitleURL := strings.TrimSpace(c.Query("url"))
+		if subtitleURL == "" {
+			abort(c, apierror.InvalidParam("url", apierror.Message{
+				EN: "Please provide an url parameter or POST the subtitle body",
+				ID: "Isi parameter url atau kirim isi subtitle dengan POST",
+			}, nil))
+			return
+		}
+		// Hanya host hasil resolver yang boleh diambil
+		validURL, validateErr := h.validator.Validate(c.Request.Context(), subtitleURL, h.urlPolicy)
+		if validateErr != nil {
+			abort(c, apierror.InvalidParam("url", apierror.Message{
+				EN: "URL must be a subtitle on a host returned by the resolver",
+				ID: "URL harus berupa subtitle dari host hasil resolver",
+			}, validateErr))
+			return
+		}
+		data, err = h.service.ConvertURL(c.Request.Context(), validURL.String(), from, to, offset)
+	}
+
+	if err != nil {
+		switch {
+		case errors.Is(err, subtitle.ErrUnknownFormat), errors.Is(err, subtitle.ErrNoCues):
+			abort(c, apierror.New(apierror.CodeInvalidParam, apierror.Message{
+				EN: "Subtitle could not be parsed",
+				ID: "Subtitle tidak dapat dibaca",
+			}, err))
+		case errors.Is(err, services.ErrProxyHostNotAllowed):
+			abort(c, apierror.New(apierror.CodeForbidden, msgHostNotAllowed, err))
+		default:
+			abort(c, upstreamError(err))
+		}
+		return
+	}
+
+	// Elemen <track> di browser membutuhkan CORS
+	c.Header("Access-Control-Allow-Origin", "*")
+	c.Data(http.StatusOK, subtitle.ContentType(to), []byte(data))
+}
+
+// parseSubtitleOffset parses an offset in seconds ("2.5", "-1") or as a Go duration ("1500ms")
+func parseSubtitleOffset(raw string) (time.Duration, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 0, nil
+	}
+
+	var offset time.Duration
+	if seconds, err := strconv.ParseFloat(raw, 64); err == nil {
+		if math.IsNaN(seconds) || math.IsInf(seconds, 0) || math.Abs(seconds) > maxSubtitleOffset.Seconds() {
+			return 0, fmt.Errorf("offset must be within ±%s", maxSubtitleOffset)
+		}
+		offset = time.Duration(seconds * float64(time.Second))
+	} else if offset, err = time.ParseDuration(raw); err != nil {
+		return 0, fmt.Errorf("offset must be seconds (e.g. 2.5) or a duration (e.g. 1500ms)")
+	}
+
+	if offset > maxSubtitleOffset || offset < -maxSubtitleOffset {
+		return 0, fmt.Errorf("offset must be within ±%s", maxSubtitleOffset)
+	}
+	return offset, nil
+}
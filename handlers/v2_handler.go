@@ -0,0 +1,385 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nabilulilalbab/dramaqu/apierror"
+	modelsv2 "github.com/nabilulilalbab/dramaqu/models/v2"
+	"github.com/nabilulilalbab/dramaqu/services"
+)
+
+// v2Source is the meta.source of every /api/v2 response
+const v2Source = "dramaqu.ad"
+
+// V2Handler serves the /api/v2 routes. Data diambil dari service yang sama
+// dengan v1 lalu dipetakan ke model v2, sehingga response v1 tidak berubah.
+type V2Handler struct {
+	home         *services.HomeService
+	animeTerbaru *services.AnimeTerbaruService
+	movie        *services.MovieService
+	schedule     *services.ScheduleService
+	search       *services.SearchService
+	detail       *services.DetailService
+	genre        *services.GenreService
+	episodes     *EpisodeDetailHandler
+}
+
+// NewV2Handler creates a new V2Handler. Episode memakai EpisodeDetailHandler agar
+// parameter resolve dan only_alive berperilaku sama seperti di v1.
+func NewV2Handler(home *services.HomeService, animeTerbaru *services.AnimeTerbaruService, movie *services.MovieService, schedule *services.ScheduleService, search *services.SearchService, detail *services.DetailService, genre *services.GenreService, episodes *EpisodeDetailHandler) *V2Handler {
+	return &V2Handler{
+		home:         home,
+		animeTerbaru: animeTerbaru,
+		movie:        movie,
+		schedule:     schedule,
+		search:       search,
+		detail:       detail,
+		genre:        genre,
+		episodes:     episodes,
+	}
+}
+
+// GetHome handles GET /api/v2/home
+// @Summary Get homepage data (v2)
+// @Description Data homepage dengan field berbahasa Inggris: top, latest_episodes, movies, schedule (per hari) dan status tiap section
+// @Tags v2
+// @Produce json
+// @Success 200 {object} v2.Envelope{data=v2.Home}
+// @Failure 502 {object} v2.Envelope
+// @Failure 504 {object} v2.Envelope
+// @Router /api/v2/home [get]
+func (h *V2Handler) GetHome(c *gin.Context) {
+	data, err := h.home.GetHomeData(c.Request.Context())
+	if err != nil {
+		abort(c, upstreamError(err))
+		return
+	}
+	h.ok(c, modelsv2.FromHome(data), modelsv2.Meta{Confidence: modelsv2.NewConfidence(data.ConfidenceScore, data.Message)})
+}
+
+// GetOngoing handles GET /api/v2/ongoing
+// @Summary Get ongoing dramas (v2)
+// @Description Daftar drama yang sedang tayang beserta episode terbarunya
+// @Tags v2
+// @Produce json,text/csv,application/x-ndjson,application/msgpack
+// @Param page query int false "Nomor halaman" default(1)
+// @Param format query string false "Format output (default JSON, juga lewat header Accept)" Enums(json, csv, ndjson, msgpack)
+// @Success 200 {object} v2.Envelope{data=[]v2.Drama}
+// @Failure 400 {object} v2.Envelope
+// @Failure 502 {object} v2.Envelope
+// @Failure 504 {object} v2.Envelope
+// @Router /api/v2/ongoing [get]
+func (h *V2Handler) GetOngoing(c *gin.Context) {
+	page, ok := h.page(c)
+	if !ok {
+		return
+	}
+	data, err := h.animeTerbaru.GetAnimeTerbaru(c.Request.Context(), page)
+	if err != nil {
+		abort(c, upstreamError(err))
+		return
+	}
+	h.ok(c, modelsv2.FromDramaEntries(data.Data), modelsv2.Meta{
+		Confidence: modelsv2.NewConfidence(data.ConfidenceScore, data.Message),
+		Pagination: modelsv2.NewPagination(page, len(data.Data)),
+	})
+}
+
+// GetMovies handles GET /api/v2/movies
+// @Summary Get movies (v2)
+// @Description Daftar film
+// @Tags v2
+// @Produce json,text/csv,application/x-ndjson,application/msgpack
+// @Param page query int false "Nomor halaman" default(1)
+// @Param format query string false "Format output (default JSON, juga lewat header Accept)" Enums(json, csv, ndjson, msgpack)
+// @Success 200 {object} v2.Envelope{data=[]v2.Drama}
+// @Failure 400 {object} v2.Envelope
+// @Failure 502 {object} v2.Envelope
+// @Failure 504 {object} v2.Envelope
+// @Router /api/v2/movies [get]
+func (h *V2Handler) GetMovies(c *gin.Context) {
+	page, ok := h.page(c)
+	if !ok {
+		return
+	}
+	data, err := h.movie.GetMovies(c.Request.Context(), page)
+	if err != nil {
+		abort(c, upstreamError(err))
+		return
+	}
+	h.ok(c, modelsv2.FromDramaDetails(data.Data), modelsv2.Meta{
+		Confidence: modelsv2.NewConfidence(data.ConfidenceScore, data.Message),
+		Pagination: modelsv2.NewPagination(page, len(data.Data)),
+	})
+}
+
+// GetSchedule handles GET /api/v2/schedule
+// @Summary Get weekly release schedule (v2)
+// @Description Jadwal rilis mingguan dengan key hari dalam huruf kecil (monday ... sunday)
+// @Tags v2
+// @Produce json
+// @Param tz query string false "Zona waktu IANA untuk day, release_time dan next_air (default Asia/Jakarta)"
+// @Success 200 {object} v2.Envelope{data=map[string][]v2.ScheduleEntry}
+// @Failure 502 {object} v2.Envelope
+// @Failure 504 {object} v2.Envelope
+// @Router /api/v2/schedule [get]
+func (h *V2Handler) GetSchedule(c *gin.Context) {
+	loc, tzErr := parseTimezone(c)
+	if tzErr != nil {
+		abort(c, tzErr)
+		return
+	}
+
+	data, err := h.schedule.GetReleaseSchedule(c.Request.Context(), loc)
+	if err != nil {
+		abort(c, upstreamError(err))
+		return
+	}
+	h.ok(c, modelsv2.FromReleaseSchedule(data.Data), modelsv2.Meta{Confidence: modelsv2.NewConfidence(data.ConfidenceScore, data.Message)})
+}
+
+// GetScheduleByDay handles GET /api/v2/schedule/:day
+// @Summary Get release schedule by day (v2)
+// @Description Jadwal rilis untuk satu hari
+// @Tags v2
+// @Produce json,text/csv,application/x-ndjson,application/msgpack
+// @Param day path string true "Nama hari dalam bahasa Inggris atau Indonesia (monday ... sunday, senin ... minggu)"
+// @Param tz query string false "Zona waktu IANA untuk day, release_time dan next_air (default Asia/Jakarta)"
+// @Param format query string false "Format output (default JSON, juga lewat header Accept)" Enums(json, csv, ndjson, msgpack)
+// @Success 200 {object} v2.Envelope{data=[]v2.ScheduleEntry}
+// @Failure 400 {object} v2.Envelope
+// @Failure 502 {object} v2.Envelope
+// @Failure 504 {object} v2.Envelope
+// @Router /api/v2/schedule/{day} [get]
+func (h *V2Handler) GetScheduleByDay(c *gin.Context) {
+	day, ok := services.ParseScheduleDay(c.Param("day"))
+	if !ok {
+		abort(c, apierror.InvalidParam("day", msgDay, nil))
+		return
+	}
+	loc, tzErr := parseTimezone(c)
+	if tzErr != nil {
+		abort(c, tzErr)
+		return
+	}
+
+	data, err := h.schedule.GetScheduleByDay(c.Request.Context(), day, loc)
+	if err != nil {
+		abort(c, upstreamError(err))
+		return
+	}
+	h.ok(c, modelsv2.FromScheduleEntries(day, data.Data), modelsv2.Meta{Confidence: modelsv2.NewConfidence(data.ConfidenceScore, data.Message)})
+}
+
+// Search handles GET /api/v2/search
+// @Summary Search dramas (v2)
+// @Description Mencari drama berdasarkan judul
+// @Tags v2
+// @Produce json,text/csv,application/x-ndjson,application/msgpack
+// @Param q query string true "Query pencarian"
+// @Param page query int false "Nomor halaman" default(1)
+// @Param format query string false "Format output (default JSON, juga lewat header Accept)" Enums(json, csv, ndjson, msgpack)
+// @Success 200 {object} v2.Envelope{data=[]v2.Drama}
+// @Failure 400 {object} v2.Envelope
+// @Failure 502 {object} v2.Envelope
+// @Failure 504 {object} v2.Envelope
+// @Router /api/v2/search [get]
+func (h *V2Handler) Search(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		abort(c, apierror.InvalidParam("q", msgQuery, nil))
+		return
+	}
+	page, ok := h.page(c)
+	if !ok {
+		return
+	}
+
+	data, err := h.search.SearchDrama(c.Request.Context(), query, page)
+	if err != nil {
+		abort(c, upstreamError(err))
+		return
+	}
+	h.ok(c, modelsv2.FromSearchDetails(data.Data), modelsv2.Meta{
+		Confidence: modelsv2.NewConfidence(data.ConfidenceScore, data.Message),
+		Pagination: modelsv2.NewPagination(page, len(data.Data)),
+	})
+}
+
+// Suggest handles GET /api/v2/search/suggest
+// @Summary Search suggestions (v2)
+// @Description Saran judul dari data yang sudah pernah di-scrape
+// @Tags v2
+// @Produce json,text/csv,application/x-ndjson,application/msgpack
+// @Param q query string true "Awalan judul"
+// @Param limit query int false "Jumlah saran maksimal (1-20)" default(8)
+// @Param format query string false "Format output (default JSON, juga lewat header Accept)" Enums(json, csv, ndjson, msgpack)
+// @Success 200 {object} v2.Envelope{data=[]v2.Drama}
+// @Failure 400 {object} v2.Envelope
+// @Router /api/v2/search/suggest [get]
+func (h *V2Handler) Suggest(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		abort(c, apierror.InvalidParam("q", msgQuery, nil))
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "8"))
+	if err != nil || limit < 1 || limit > 20 {
+		abort(c, apierror.InvalidParam("limit", msgLimit, nil))
+		return
+	}
+
+	data := h.search.Suggest(query, limit)
+	h.ok(c, modelsv2.FromSuggestItems(data.Data), modelsv2.Meta{Confidence: modelsv2.NewConfidence(data.ConfidenceScore, data.Message)})
+}
+
+// GetGenres handles GET /api/v2/genres
+// @Summary Get genres (v2)
+// @Description Daftar genre beserta jumlah drama
+// @Tags v2
+// @Produce json,text/csv,application/x-ndjson,application/msgpack
+// @Param format query string false "Format output (default JSON, juga lewat header Accept)" Enums(json, csv, ndjson, msgpack)
+// @Success 200 {object} v2.Envelope{data=[]v2.Genre}
+// @Failure 502 {object} v2.Envelope
+// @Failure 504 {object} v2.Envelope
+// @Router /api/v2/genres [get]
+func (h *V2Handler) GetGenres(c *gin.Context) {
+	data, err := h.genre.GetGenres(c.Request.Context())
+	if err != nil {
+		abort(c, upstreamError(err))
+		return
+	}
+	h.ok(c, modelsv2.FromGenres(data.Data), modelsv2.Meta{Confidence: modelsv2.NewConfidence(data.ConfidenceScore, data.Message)})
+}
+
+// GetDramasByGenre handles GET /api/v2/genres/:slug
+// @Summary Get dramas by genre (v2)
+// @Description Daftar drama pada satu genre
+// @Tags v2
+// @Produce json,text/csv,application/x-ndjson,application/msgpack
+// @Param slug path string true "Slug genre"
+// @Param page query int false "Nomor halaman" default(1)
+// @Param format query string false "Format output (default JSON, juga lewat header Accept)" Enums(json, csv, ndjson, msgpack)
+// @Success 200 {object} v2.Envelope{data=[]v2.Drama}
+// @Failure 400 {object} v2.Envelope
+// @Failure 502 {object} v2.Envelope
+// @Failure 504 {object} v2.Envelope
+// @Router /api/v2/genres/{slug} [get]
+func (h *V2Handler) GetDramasByGenre(c *gin.Context) {
+	slug := c.Param("slug")
+	if !slugPattern.MatchString(slug) {
+		abort(c, apierror.InvalidParam("slug", msgSlug, nil))
+		return
+	}
+	page, ok := h.page(c)
+	if !ok {
+		return
+	}
+
+	data, err := h.genre.GetDramasByGenre(c.Request.Context(), slug, page)
+	if err != nil {
+		abort(c, upstreamError(err))
+		return
+	}
+	h.ok(c, modelsv2.FromDramaDetails(data.Data), modelsv2.Meta{
+		Confidence: modelsv2.NewConfidence(data.ConfidenceScore, data.Message),
+		Pagination: modelsv2.NewPagination(page, len(data.Data)),
+	})
+}
+
+// GetDrama handles GET /api/v2/dramas/:slug
+// @Summary Get drama detail (v2)
+// @Description Detail drama: info, rating, daftar episode dan rekomendasi
+// @Tags v2
+// @Produce json
+// @Param slug path string true "Slug drama"
+// @Success 200 {object} v2.Envelope{data=v2.DramaDetail}
+// @Failure 400 {object} v2.Envelope
+// @Failure 404 {object} v2.Envelope
+// @Failure 502 {object} v2.Envelope
+// @Failure 504 {object} v2.Envelope
+// @Router /api/v2/dramas/{slug} [get]
+func (h *V2Handler) GetDrama(c *gin.Context) {
+	slug := c.Param("slug")
+	if !slugPattern.MatchString(slug) {
+		abort(c, apierror.InvalidParam("slug", msgSlug, nil))
+		return
+	}
+
+	data, err := h.detail.GetDetailDrama(c.Request.Context(), slug)
+	if err != nil {
+		abort(c, upstreamError(err))
+		return
+	}
+	setLastModified(c, h.detail.LastScraped(slug))
+	h.ok(c, modelsv2.FromDetail(data), modelsv2.Meta{Confidence: modelsv2.NewConfidence(data.ConfidenceScore, data.Message)})
+}
+
+// GetEpisode handles GET /api/v2/dramas/:slug/episodes/:n
+// @Summary Get episode detail (v2)
+// @Description Detail episode: server streaming, subtitle, link download (list datar per format dan kualitas) dan navigasi
+// @Tags v2
+// @Produce json
+// @Param slug path string true "Slug drama"
+// @Param n path string true "Nomor episode atau episode slug ('{slug}-episode-{n}')"
+// @Param resolve query bool false "Resolve server streaming menjadi URL media langsung" default(false)
+// @Param only_alive query bool false "Hanya server yang lolos health check" default(false)
+// @Success 200 {object} v2.Envelope{data=v2.Episode}
+// @Failure 400 {object} v2.Envelope
+// @Failure 404 {object} v2.Envelope
+// @Failure 502 {object} v2.Envelope
+// @Failure 504 {object} v2.Envelope
+// @Router /api/v2/dramas/{slug}/episodes/{n} [get]
+func (h *V2Handler) GetEpisode(c *gin.Context) {
+	slug := c.Param("slug")
+	if !slugPattern.MatchString(slug) {
+		abort(c, apierror.InvalidParam("slug", msgSlug, nil))
+		return
+	}
+	n, err := services.ParseEpisodeRef(slug, c.Param("n"))
+	if err != nil {
+		abort(c, apierror.InvalidParam("n", msgEpisodeRef, err))
+		return
+	}
+
+	data, err := h.episodes.service.GetEpisodeBySlug(c.Request.Context(), slug, n)
+	if err != nil {
+		abort(c, upstreamError(err))
+		return
+	}
+	h.episodes.enrich(c, data)
+	setLastModified(c, h.episodes.service.LastScraped(slug))
+	h.ok(c, modelsv2.FromEpisodeDetail(data), modelsv2.Meta{Confidence: modelsv2.NewConfidence(data.ConfidenceScore, data.Message)})
+}
+
+// page reads the page query parameter and writes a 400 when it is invalid
+func (h *V2Handler) page(c *gin.Context) (int, bool) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		abort(c, apierror.InvalidParam("page", msgPage, nil))
+		return 0, false
+	}
+	return page, true
+}
+
+// ok writes a successful envelope
+func (h *V2Handler) ok(c *gin.Context, data interface{}, meta modelsv2.Meta) {
+	meta.Source = v2Source
+	c.JSON(http.StatusOK, modelsv2.Envelope{Data: data, Meta: meta, Errors: []modelsv2.Error{}})
+}
+
+// RenderV2Error renders an API error as a /api/v2 envelope with data null
+func RenderV2Error(c *gin.Context, err *apierror.Error, lang, requestID string) {
+	c.JSON(err.Status, modelsv2.Envelope{
+		Meta: modelsv2.Meta{Source: v2Source},
+		Errors: []modelsv2.Error{{
+			Code:      string(err.Code),
+			Message:   err.Detail(lang),
+			Param:     err.Param,
+			RequestID: requestID,
+		}},
+	})
+}
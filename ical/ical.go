@@ -0,0 +1,161 @@
+// Package ical menyusun dokumen iCalendar (RFC 5545) untuk jadwal rilis drama,
+// sehingga jadwal bisa ditambahkan ke aplikasi kalender.
+package ical
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ContentType is the media type of an encoded calendar
+const ContentType = "text/calendar; charset=utf-8"
+
+// maxLineOctets is the maximum length of a content line before folding (RFC 5545 3.1)
+const maxLineOctets = 75
+
+// Calendar is a calendar of weekly recurring events in one timezone
+type Calendar struct {
+	ProdID      string
+	Name        string
+	Description string
+	// TZID is the IANA name of Location, used in VTIMEZONE and every DTSTART
+	TZID     string
+	Location *time.Location
+	// Stamp is the DTSTAMP of every event; gunakan nilai yang stabil (misalnya awal
+	// minggu jadwal diambil) agar dokumen yang sama menghasilkan ETag yang sama
+	Stamp  time.Time
+	Events []Event
+}
+
+// Event is one VEVENT that repeats every week on the weekday of Start
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	URL         string
+	// Image is the cover URL (RFC 7986 IMAGE property)
+	Image    string
+	Start    time.Time
+	Duration time.Duration
+}
+
+// weekdayCodes maps weekdays to RRULE BYDAY values
+var weekdayCodes = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Encode writes the calendar as an iCalendar document
+func Encode(cal *Calendar) []byte {
+	var w writer
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.property("PRODID", cal.ProdID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	if cal.Name != "" {
+		w.property("X-WR-CALNAME", escapeText(cal.Name))
+	}
+	if cal.Description != "" {
+		w.property("X-WR-CALDESC", escapeText(cal.Description))
+	}
+	w.property("X-WR-TIMEZONE", cal.TZID)
+	w.timezone(cal.TZID, cal.Location, cal.Stamp)
+
+	stamp := cal.Stamp.UTC().Format("20060102T150405Z")
+	for _, event := range cal.Events {
+		start := event.Start.In(cal.Location)
+		w.line("BEGIN:VEVENT")
+		w.property("UID", event.UID)
+		w.property("DTSTAMP", stamp)
+		w.property("DTSTART;TZID="+cal.TZID, start.Format("20060102T150405"))
+		w.property("DURATION", formatDuration(event.Duration))
+		w.property("RRULE", "FREQ=WEEKLY;BYDAY="+weekdayCodes[start.Weekday()])
+		w.property("SUMMARY", escapeText(event.Summary))
+		if event.Description != "" {
+			w.property("DESCRIPTION", escapeText(event.Description))
+		}
+		if event.URL != "" {
+			w.property("URL;VALUE=URI", event.URL)
+		}
+		if event.Image != "" {
+			w.property("IMAGE;VALUE=URI;DISPLAY=THUMBNAIL", event.Image)
+		}
+		w.line("END:VEVENT")
+	}
+	w.line("END:VCALENDAR")
+	return []byte(w.String())
+}
+
+// timezone writes a VTIMEZONE with the offset of loc at t.
+// Zona tanpa daylight saving (seperti Asia/Jakarta) cukup satu komponen STANDARD.
+func (w *writer) timezone(tzid string, loc *time.Location, t time.Time) {
+	name, offset := t.In(loc).Zone()
+	w.line("BEGIN:VTIMEZONE")
+	w.property("TZID", tzid)
+	w.line("BEGIN:STANDARD")
+	w.line("DTSTART:19700101T000000")
+	w.property("TZOFFSETFROM", formatOffset(offset))
+	w.property("TZOFFSETTO", formatOffset(offset))
+	w.property("TZNAME", name)
+	w.line("END:STANDARD")
+	w.line("END:VTIMEZONE")
+}
+
+// formatOffset formats a UTC offset in seconds as +HHMM
+func formatOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, offset/3600, offset%3600/60)
+}
+
+// formatDuration formats a duration as an iCalendar DURATION value such as PT1H30M
+func formatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "PT0S"
+	}
+	value := "PT"
+	if hours := int(d.Hours()); hours > 0 {
+		value += fmt.Sprintf("%dH", hours)
+	}
+	if minutes := int(d.Minutes()) % 60; minutes > 0 {
+		value += fmt.Sprintf("%dM", minutes)
+	}
+	if seconds := int(d.Seconds()) % 60; seconds > 0 {
+		value += fmt.Sprintf("%dS", seconds)
+	}
+	return value
+}
+
+// escapeText escapes a TEXT value (RFC 5545 3.3.11)
+func escapeText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// writer builds content lines with CRLF endings and folding
+type writer struct {
+	strings.Builder
+}
+
+func (w *writer) property(name, value string) {
+	w.line(name + ":" + value)
+}
+
+// line writes one content line, folded after maxLineOctets without splitting UTF-8 characters
+func (w *writer) line(content string) {
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		// Mundur sampai awal karakter UTF-8 agar karakter multi-byte tidak terpotong
+		for cut > 0 && content[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		w.WriteString(content[:cut])
+		w.WriteString("\r\n ")
+		content = content[cut:]
+		// Baris lanjutan diawali spasi yang ikut dihitung
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(content)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,81 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncode(t *testing.T) {
+	wib := time.FixedZone("WIB", 7*60*60)
+	cal := &Calendar{
+		ProdID:   "-//DramaQu//Jadwal Rilis//ID",
+		Name:     "Jadwal Rilis DramaQu",
+		TZID:     "Asia/Jakarta",
+		Location: wib,
+		Stamp:    time.Date(2026, 10, 19, 1, 0, 0, 0, time.UTC),
+		Events: []Event{{
+			UID:         "judul-drama@dramaqu.ad",
+			Summary:     "Judul Drama; Season 2, Part 1",
+			Description: "Nonton: https://dramaqu.ad/judul-drama/\nCover: https://dramaqu.ad/cover.jpg",
+			URL:         "https://dramaqu.ad/judul-drama/",
+			Image:       "https://dramaqu.ad/cover.jpg",
+			Start:       time.Date(2026, 10, 21, 20, 30, 0, 0, wib),
+			Duration:    time.Hour,
+		}},
+	}
+	got := string(Encode(cal))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"BEGIN:VTIMEZONE\r\nTZID:Asia/Jakarta\r\nBEGIN:STANDARD\r\nDTSTART:19700101T000000\r\nTZOFFSETFROM:+0700\r\nTZOFFSETTO:+0700\r\nTZNAME:WIB\r\n",
+		"UID:judul-drama@dramaqu.ad\r\n",
+		"DTSTAMP:20261019T010000Z\r\n",
+		"DTSTART;TZID=Asia/Jakarta:20261021T203000\r\n",
+		"DURATION:PT1H\r\n",
+		"RRULE:FREQ=WEEKLY;BYDAY=WE\r\n",
+		`SUMMARY:Judul Drama\; Season 2\, Part 1` + "\r\n",
+		"END:VEVENT\r\nEND:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in\n%s", want, got)
+		}
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line longer than %d octets: %q", maxLineOctets, line)
+		}
+	}
+	// Baris yang dilipat harus kembali utuh setelah unfolding
+	unfolded := strings.ReplaceAll(got, "\r\n ", "")
+	if !strings.Contains(unfolded, `DESCRIPTION:Nonton: https://dramaqu.ad/judul-drama/\nCover: https://dramaqu.ad/cover.jpg`) {
+		t.Errorf("description not preserved:\n%s", unfolded)
+	}
+}
+
+func TestFoldKeepsUTF8(t *testing.T) {
+	var w writer
+	w.property("SUMMARY", strings.Repeat("드라마", 20))
+	for _, line := range strings.Split(strings.TrimSuffix(w.String(), "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line longer than %d octets: %q", maxLineOctets, line)
+		}
+	}
+	if unfolded := strings.ReplaceAll(w.String(), "\r\n ", ""); unfolded != "SUMMARY:"+strings.Repeat("드라마", 20)+"\r\n" {
+		t.Errorf("unfolded = %q", unfolded)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		time.Hour:                    "PT1H",
+		90 * time.Minute:             "PT1H30M",
+		45*time.Minute + time.Second: "PT45M1S",
+		0:                            "PT0S",
+	} {
+		if got := formatDuration(d); got != want {
+			t.Errorf("formatDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -1,14 +1,21 @@
 package main
 
 import (
+	"context"
 	"log"
+	"time"
+	_ "time/tzdata" // Database zona waktu untuk ?tz= di image tanpa zoneinfo
 
 	"github.com/gin-gonic/gin"
+	"github.com/nabilulilalbab/dramaqu/catalog"
 	"github.com/nabilulilalbab/dramaqu/config"
 	"github.com/nabilulilalbab/dramaqu/handlers"
 	"github.com/nabilulilalbab/dramaqu/middleware"
+	"github.com/nabilulilalbab/dramaqu/resolver"
 	"github.com/nabilulilalbab/dramaqu/routes"
 	"github.com/nabilulilalbab/dramaqu/services"
+	"github.com/nabilulilalbab/dramaqu/signer"
+	"github.com/nabilulilalbab/dramaqu/urlguard"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
@@ -41,29 +48,69 @@ func main() {
 
 	r := gin.Default()
 
+	// X-Forwarded-For hanya dipercaya dari proxy yang dikonfigurasi, agar IP client
+	// (dipakai untuk link stream yang diikat ke IP) tidak bisa dipalsukan
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES:", err)
+	}
+
 	// Add middleware for dynamic host detection
 	r.Use(middleware.DynamicSwaggerHost())
 
+	// Initialize in-memory catalog shared by all services
+	dramaCatalog := catalog.New()
+
+	// Situs sumber yang di-scrape oleh semua service
+	site := services.NewSite(cfg.BaseURL, cfg.AllowedHosts)
+
+	// Initialize embed resolvers (satu resolver per host player)
+	resolverRegistry := resolver.NewRegistry(resolver.NewHTTPFetcher(30*time.Second), resolver.Builtins(site.Referer())...)
+
+	// Initialize signer untuk link stream/proxy
+	streamSecret := []byte(cfg.StreamSecret)
+	if len(streamSecret) == 0 {
+		log.Printf("STREAM_SECRET tidak diset, memakai secret acak (link stream tidak berlaku setelah restart)")
+		streamSecret = signer.RandomSecret()
+	}
+	streamSigner := signer.New(streamSecret, cfg.StreamLinkTTL, cfg.StreamBindIP)
+
+	// Validator untuk semua parameter URL dari user (proteksi SSRF)
+	urlValidator := urlguard.New(nil)
+
 	// Initialize services
-	homeService := services.NewHomeService()
-	animeTerbaruService := services.NewAnimeTerbaruService()
-	movieService := services.NewMovieService()
-	scheduleService := services.NewScheduleService()
-	searchService := services.NewSearchService()
-	detailService := services.NewDetailService()
-	episodeDetailService := services.NewEpisodeDetailService()
+	homeService := services.NewHomeService(dramaCatalog, site)
+	animeTerbaruService := services.NewAnimeTerbaruService(dramaCatalog, site)
+	movieService := services.NewMovieService(dramaCatalog, site)
+	scheduleService := services.NewScheduleService(dramaCatalog, site)
+	searchService := services.NewSearchService(dramaCatalog, site)
+	detailService := services.NewDetailService(dramaCatalog, site)
+	episodeDetailService := services.NewEpisodeDetailService(dramaCatalog, site)
+	genreService := services.NewGenreService(dramaCatalog, site)
+	streamService := services.NewStreamService(resolverRegistry, streamSigner, urlValidator)
+	subtitleService := services.NewSubtitleService(streamService)
+	feedService := services.NewFeedService(animeTerbaruService, detailService, dramaCatalog)
+	upcomingService := services.NewUpcomingService(scheduleService, animeTerbaruService)
+	healthCheckService := services.NewHealthCheckService(dramaCatalog, site, cfg.HealthCheckInterval)
+
+	// Health check server streaming berjalan di background
+	go healthCheckService.Start(context.Background())
 
 	// Initialize handlers
 	homeHandler := handlers.NewHomeHandler(homeService)
 	animeTerbaruHandler := handlers.NewAnimeTerbaruHandler(animeTerbaruService)
 	movieHandler := handlers.NewMovieHandler(movieService)
-	scheduleHandler := handlers.NewScheduleHandler(scheduleService)
+	scheduleHandler := handlers.NewScheduleHandler(scheduleService, upcomingService)
 	searchHandler := handlers.NewSearchHandler(searchService)
 	detailHandler := handlers.NewDetailHandler(detailService)
-	episodeDetailHandler := handlers.NewEpisodeDetailHandler(episodeDetailService)
+	episodeDetailHandler := handlers.NewEpisodeDetailHandler(episodeDetailService, streamService, healthCheckService, urlValidator)
+	genreHandler := handlers.NewGenreHandler(genreService)
+	streamHandler := handlers.NewStreamHandler(streamService, urlValidator)
+	subtitleHandler := handlers.NewSubtitleHandler(subtitleService, urlValidator)
+	v2Handler := handlers.NewV2Handler(homeService, animeTerbaruService, movieService, scheduleService, searchService, detailService, genreService, episodeDetailHandler)
+	feedHandler := handlers.NewFeedHandler(feedService, cfg.PublicURL)
 
 	// Setup routes
-	routes.SetupRoutes(r, homeHandler, animeTerbaruHandler, movieHandler, scheduleHandler, searchHandler, detailHandler, episodeDetailHandler)
+	routes.SetupRoutes(r, homeHandler, animeTerbaruHandler, movieHandler, scheduleHandler, searchHandler, detailHandler, episodeDetailHandler, genreHandler, streamHandler, subtitleHandler, v2Handler, feedHandler, streamSigner, cfg.RequestTimeout, cfg.Cache)
 
 	// Dynamic swagger config endpoint
 	r.GET("/swagger-config", middleware.SwaggerConfigHandler())
@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Cache middleware menambahkan semantik HTTP caching pada response JSON yang berhasil:
+// ETag kuat dari hash body, Cache-Control dengan max-age dan stale-while-revalidate,
+// serta 304 Not Modified untuk If-None-Match (atau If-Modified-Since jika handler
+// mengisi header Last-Modified). Response selain 200 diteruskan apa adanya.
+// maxAge 0 berarti response tidak di-cache (Cache-Control: no-cache), tetapi ETag
+// tetap dikirim agar client bisa revalidasi.
+func Cache(maxAge, staleWhileRevalidate time.Duration) gin.HandlerFunc {
+	cacheControl := cacheControlValue(maxAge, staleWhileRevalidate)
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		buffer := &bufferedWriter{ResponseWriter: original}
+		c.Writer = buffer
+		c.Next()
+		c.Writer = original
+
+		// Handler tidak menulis apa pun (misalnya error untuk RenderErrors)
+		if !buffer.Written() {
+			return
+		}
+		if buffer.Status() != http.StatusOK {
+			buffer.flush()
+			return
+		}
+
+		sum := sha256.Sum256(buffer.body.Bytes())
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		header := original.Header()
+		header.Set("ETag", etag)
+		// Handler boleh menentukan Cache-Control sendiri, misalnya untuk data per client
+		if header.Get("Cache-Control") == "" {
+			header.Set("Cache-Control", cacheControl)
+		}
+
+		if notModified(c.Request, etag, header.Get("Last-Modified")) {
+			header.Del("Content-Length")
+			original.WriteHeader(http.StatusNotModified)
+			original.WriteHeaderNow()
+			return
+		}
+		buffer.flush()
+	}
+}
+
+// cacheControlValue builds the Cache-Control header of a policy
+func cacheControlValue(maxAge, staleWhileRevalidate time.Duration) string {
+	if maxAge <= 0 {
+		return "no-cache"
+	}
+	value := fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds()))
+	if staleWhileRevalidate > 0 {
+		value += fmt.Sprintf(", stale-while-revalidate=%d", int(staleWhileRevalidate.Seconds()))
+	}
+	return value
+}
+
+// notModified evaluates the conditional request headers (RFC 9110 13.2.2):
+// If-None-Match lebih diutamakan; If-Modified-Since hanya dipakai jika tidak ada
+func notModified(r *http.Request, etag, lastModified string) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return etagMatches(inm, etag)
+	}
+
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || lastModified == "" {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
+
+// etagMatches compares an If-None-Match list with etag using weak comparison.
+// ETag representasi terkompresi dari Compress ("...-br", "...-gzip") juga cocok.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		switch candidate {
+		case "*", etag, encodedETag(etag, encodingBrotli), encodedETag(etag, encodingGzip):
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedWriter holds the response so its ETag can be computed before sending
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body   bytes.Buffer
+	status int
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	w.WriteHeaderNow()
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.WriteHeaderNow()
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.status != 0
+}
+
+// flush sends the buffered status and body to the underlying writer
+func (w *bufferedWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.Status())
+	w.ResponseWriter.Write(w.body.Bytes())
+}
@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newCacheRouter(lastModified time.Time) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/data", Cache(5*time.Minute, time.Hour), func(c *gin.Context) {
+		if !lastModified.IsZero() {
+			c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+		}
+		c.JSON(http.StatusOK, gin.H{"judul": "Drama"})
+	})
+	r.GET("/private", Cache(5*time.Minute, time.Hour), func(c *gin.Context) {
+		c.Header("Cache-Control", "private, no-cache")
+		c.JSON(http.StatusOK, gin.H{"judul": "Drama"})
+	})
+	r.GET("/error", Cache(5*time.Minute, time.Hour), func(c *gin.Context) {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "upstream"})
+	})
+	return r
+}
+
+func serve(r *gin.Engine, path string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCacheETagAndConditionalRequest(t *testing.T) {
+	r := newCacheRouter(time.Time{})
+
+	first := serve(r, "/data", nil)
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || first.Body.String() != `{"judul":"Drama"}` {
+		t.Fatalf("first response = %d %q", first.Code, first.Body.String())
+	}
+	if len(etag) != 34 || etag[0] != '"' {
+		t.Errorf("ETag = %q, want a quoted strong validator", etag)
+	}
+	if got := first.Header().Get("Cache-Control"); got != "public, max-age=300, stale-while-revalidate=3600" {
+		t.Errorf("Cache-Control = %q", got)
+	}
+
+	if again := serve(r, "/data", nil); again.Header().Get("ETag") != etag {
+		t.Errorf("ETag changed for the same body: %q != %q", again.Header().Get("ETag"), etag)
+	}
+
+	for _, inm := range []string{etag, `"other", ` + etag, "W/" + etag, "*"} {
+		w := serve(r, "/data", http.Header{"If-None-Match": {inm}})
+		if w.Code != http.StatusNotModified || w.Body.Len() != 0 || w.Header().Get("ETag") != etag {
+			t.Errorf("If-None-Match %q: got %d with %d bytes", inm, w.Code, w.Body.Len())
+		}
+	}
+
+	if w := serve(r, "/data", http.Header{"If-None-Match": {`"other"`}}); w.Code != http.StatusOK || w.Body.Len() == 0 {
+		t.Errorf("stale ETag: got %d", w.Code)
+	}
+}
+
+func TestCacheLastModified(t *testing.T) {
+	scraped := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	r := newCacheRouter(scraped)
+
+	if w := serve(r, "/data", nil); w.Header().Get("Last-Modified") != "Mon, 19 Oct 2026 08:00:00 GMT" {
+		t.Errorf("Last-Modified = %q", w.Header().Get("Last-Modified"))
+	}
+	if w := serve(r, "/data", http.Header{"If-Modified-Since": {"Mon, 19 Oct 2026 09:00:00 GMT"}}); w.Code != http.StatusNotModified {
+		t.Errorf("If-Modified-Since after scrape: got %d, want 304", w.Code)
+	}
+	if w := serve(r, "/data", http.Header{"If-Modified-Since": {"Mon, 19 Oct 2026 07:00:00 GMT"}}); w.Code != http.StatusOK {
+		t.Errorf("If-Modified-Since before scrape: got %d, want 200", w.Code)
+	}
+	// If-None-Match lebih diutamakan daripada If-Modified-Since
+	w := serve(r, "/data", http.Header{"If-None-Match": {`"other"`}, "If-Modified-Since": {"Mon, 19 Oct 2026 09:00:00 GMT"}})
+	if w.Code != http.StatusOK {
+		t.Errorf("mismatching If-None-Match with If-Modified-Since: got %d, want 200", w.Code)
+	}
+}
+
+func TestCacheSkipsErrorsAndKeepsHandlerPolicy(t *testing.T) {
+	r := newCacheRouter(time.Time{})
+
+	w := serve(r, "/error", nil)
+	if w.Code != http.StatusBadGateway || w.Header().Get("ETag") != "" || w.Header().Get("Cache-Control") != "" {
+		t.Errorf("error response: %d, ETag %q, Cache-Control %q", w.Code, w.Header().Get("ETag"), w.Header().Get("Cache-Control"))
+	}
+	if w.Body.String() != `{"error":"upstream"}` {
+		t.Errorf("error body = %q", w.Body.String())
+	}
+
+	if w := serve(r, "/private", nil); w.Header().Get("Cache-Control") != "private, no-cache" {
+		t.Errorf("Cache-Control = %q, want the handler's value", w.Header().Get("Cache-Control"))
+	}
+}
@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+// Supported content codings, in order of preference
+const (
+	encodingBrotli = "br"
+	encodingGzip   = "gzip"
+)
+
+// brotliLevel trades ratio for speed; level di atas 5 terlalu lambat untuk response dinamis
+const brotliLevel = 5
+
+// compressibleTypes are the media types worth compressing
+var compressibleTypes = map[string]bool{
+	"application/json":     true,
+	"application/x-ndjson": true,
+	"application/xml":      true,
+	"application/rss+xml":  true,
+	"application/atom+xml": true,
+	"text/plain":           true,
+	"text/csv":             true,
+	"text/vtt":             true,
+	"text/calendar":        true,
+	"text/html":            true,
+	"text/xml":             true,
+	"application/x-subrip": true,
+	"text/x-ssa":           true,
+}
+
+// Compress middleware mengompres response dengan brotli atau gzip sesuai
+// Accept-Encoding. Hanya media type teks/JSON yang dikompres; response tanpa body
+// (304, HEAD) dan response yang sudah punya Content-Encoding diteruskan apa adanya.
+// ETag kuat diberi akhiran encoding karena representasinya berbeda.
+func Compress() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		writer := &compressWriter{ResponseWriter: original, encoding: encoding}
+		c.Writer = writer
+		c.Next()
+		writer.close()
+		c.Writer = original
+	}
+}
+
+// negotiateEncoding picks brotli or gzip from an Accept-Encoding header, "" for identity
+func negotiateEncoding(acceptEncoding string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if coding == "*" {
+			coding = encodingBrotli
+		}
+		if coding != encodingBrotli && coding != encodingGzip || q <= 0 {
+			continue
+		}
+		// Pada q yang sama brotli diutamakan karena hasilnya lebih kecil
+		if q > bestQ || q == bestQ && coding == encodingBrotli {
+			best, bestQ = coding, q
+		}
+	}
+	return best
+}
+
+// encodedETag returns the ETag of the compressed representation of etag
+func encodedETag(etag, encoding string) string {
+	if !strings.HasPrefix(etag, `"`) {
+		return etag
+	}
+	return strings.TrimSuffix(etag, `"`) + "-" + encoding + `"`
+}
+
+// compressWriter compresses the body once the response turns out to be compressible
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	encoder  io.WriteCloser
+	started  bool
+}
+
+// start decides whether to compress, just before the header is sent
+func (w *compressWriter) start() {
+	if w.started {
+		return
+	}
+	w.started = true
+
+	header := w.Header()
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	if !compressibleTypes[mediaType] || header.Get("Content-Encoding") != "" {
+		return
+	}
+	if etag := header.Get("ETag"); etag != "" {
+		header.Set("ETag", encodedETag(etag, w.encoding))
+	}
+
+	status := w.ResponseWriter.Status()
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		return
+	}
+	header.Set("Content-Encoding", w.encoding)
+	header.Del("Content-Length")
+	if w.encoding == encodingBrotli {
+		w.encoder = brotli.NewWriterLevel(w.ResponseWriter, brotliLevel)
+	} else {
+		w.encoder = gzip.NewWriter(w.ResponseWriter)
+	}
+}
+
+func (w *compressWriter) WriteHeaderNow() {
+	w.start()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	w.start()
+	if w.encoder == nil {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.encoder.Write(data)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressWriter) Flush() {
+	if flusher, ok := w.encoder.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// close finishes the compressed stream
+func (w *compressWriter) close() {
+	if w.encoder != nil {
+		w.encoder.Close()
+	}
+}
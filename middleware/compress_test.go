@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		want           string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"gzip", "gzip"},
+		{"gzip, deflate, br", "br"},
+		{"br;q=0.5, gzip", "gzip"},
+		{"br;q=0, gzip;q=0", ""},
+		{"*", "br"},
+		{"GZIP;q=0.8", "gzip"},
+	}
+	for _, tt := range tests {
+		if got := negotiateEncoding(tt.acceptEncoding); got != tt.want {
+			t.Errorf("negotiateEncoding(%q) = %q, want %q", tt.acceptEncoding, got, tt.want)
+		}
+	}
+}
+
+func newCompressRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Compress())
+	r.GET("/data", Cache(5*time.Minute, time.Hour), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"judul": strings.Repeat("Drama ", 100)})
+	})
+	r.GET("/image", func(c *gin.Context) {
+		c.Data(http.StatusOK, "image/jpeg", []byte("jpeg"))
+	})
+	return r
+}
+
+func TestCompressRoundTrip(t *testing.T) {
+	r := newCompressRouter()
+	plain := serve(r, "/data", nil)
+	if plain.Header().Get("Content-Encoding") != "" || plain.Header().Get("Vary") != "Accept-Encoding" {
+		t.Fatalf("identity response: Content-Encoding %q, Vary %q", plain.Header().Get("Content-Encoding"), plain.Header().Get("Vary"))
+	}
+
+	decoders := map[string]func(io.Reader) (io.Reader, error){
+		"gzip": func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		"br":   func(r io.Reader) (io.Reader, error) { return brotli.NewReader(r), nil },
+	}
+	for encoding, decode := range decoders {
+		w := serve(r, "/data", http.Header{"Accept-Encoding": {encoding}})
+		if got := w.Header().Get("Content-Encoding"); got != encoding {
+			t.Errorf("%s: Content-Encoding = %q", encoding, got)
+			continue
+		}
+		reader, err := decode(w.Body)
+		if err != nil {
+			t.Fatalf("%s: %v", encoding, err)
+		}
+		body, err := io.ReadAll(reader)
+		if err != nil || string(body) != plain.Body.String() {
+			t.Errorf("%s: decoded body differs (%v)", encoding, err)
+		}
+
+		etag := w.Header().Get("ETag")
+		if want := encodedETag(plain.Header().Get("ETag"), encoding); etag != want {
+			t.Errorf("%s: ETag = %q, want %q", encoding, etag, want)
+		}
+		notModified := serve(r, "/data", http.Header{"Accept-Encoding": {encoding}, "If-None-Match": {etag}})
+		if notModified.Code != http.StatusNotModified || notModified.Body.Len() != 0 {
+			t.Errorf("%s: If-None-Match %q got %d with %d bytes", encoding, etag, notModified.Code, notModified.Body.Len())
+		}
+	}
+}
+
+func TestCompressSkipsBinary(t *testing.T) {
+	w := serve(newCompressRouter(), "/image", http.Header{"Accept-Encoding": {"gzip"}})
+	if w.Header().Get("Content-Encoding") != "" || w.Body.String() != "jpeg" {
+		t.Errorf("image: Content-Encoding %q, body %q", w.Header().Get("Content-Encoding"), w.Body.String())
+	}
+}
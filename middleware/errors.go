@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nabilulilalbab/dramaqu/apierror"
+)
+
+// ErrorRenderer writes the response body of an API error
+type ErrorRenderer func(c *gin.Context, err *apierror.Error, lang, requestID string)
+
+// RenderErrors middleware merender error yang dicatat handler lewat c.Error.
+// Handler cukup memanggil c.Error(err) lalu return; status, kode, pesan sesuai
+// Accept-Language dan request ID ditentukan di sini. Error yang bukan
+// *apierror.Error dianggap INTERNAL dan penyebab aslinya hanya di-log.
+func RenderErrors(render ErrorRenderer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := apierror.From(c.Errors.Last().Err)
+		requestID := GetRequestID(c)
+		log.Printf("[%s] %s %s -> %d %s", requestID, c.Request.Method, c.Request.URL.Path, err.Status, err)
+		render(c, err, apierror.Language(c.GetHeader("Accept-Language")), requestID)
+	}
+}
+
+// JSONError renders the /api/v1 error body
+func JSONError(c *gin.Context, err *apierror.Error, lang, requestID string) {
+	c.JSON(err.Status, err.Response(lang, requestID))
+}
+
+// abortWithError records err for RenderErrors and stops the handler chain
+func abortWithError(c *gin.Context, err *apierror.Error) {
+	c.Error(err)
+	c.Abort()
+}
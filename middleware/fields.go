@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nabilulilalbab/dramaqu/apierror"
+)
+
+// maxFields limits the number of paths in one fields parameter
+const maxFields = 50
+
+// fieldSegmentPattern matches one segment of a field path (nama field JSON)
+var fieldSegmentPattern = regexp.MustCompile(`^[A-Za-z0-9_]{1,64}$`)
+
+// fieldSelectionKey stores the fieldSelection of a request in the gin context
+const fieldSelectionKey = "middleware.fields"
+
+// fieldSelection is the parsed ?fields= of a request, dipakai Format untuk header CSV
+type fieldSelection struct {
+	fields fieldSet
+	root   string
+}
+
+// fieldSet is a parsed sparse fieldset. Nilai nil berarti field diambil utuh,
+// selain itu hanya sub-field di dalamnya yang diambil.
+type fieldSet map[string]fieldSet
+
+// Fields middleware menerapkan sparse fieldset ?fields= pada response JSON yang
+// berhasil, contoh fields=judul,cover,episode_list.episode. Path bertitik memilih
+// sub-field; array dilewati sehingga episode_list.episode berlaku untuk setiap
+// elemen. root adalah field yang difilter (misalnya "data" pada envelope v2),
+// kosong untuk seluruh body. Field yang tidak ada diabaikan.
+func Fields(root string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw := c.Query("fields")
+		if raw == "" {
+			c.Next()
+			return
+		}
+		fields, err := parseFields(raw)
+		if err != nil {
+			abortWithError(c, apierror.InvalidParam("fields", apierror.Message{
+				EN: "Fields must be a comma separated list of field names, use dots for nested fields (judul,episode_list.episode)",
+				ID: "Fields harus berupa daftar nama field dipisah koma, gunakan titik untuk field bertingkat (judul,episode_list.episode)",
+			}, err))
+			return
+		}
+		c.Set(fieldSelectionKey, fieldSelection{fields: fields, root: root})
+
+		original := c.Writer
+		buffer := &bufferedWriter{ResponseWriter: original}
+		c.Writer = buffer
+		c.Next()
+		c.Writer = original
+
+		if !buffer.Written() {
+			return
+		}
+		mediaType, _, _ := mime.ParseMediaType(original.Header().Get("Content-Type"))
+		if buffer.Status() != http.StatusOK || mediaType != "application/json" {
+			buffer.flush()
+			return
+		}
+
+		filtered, err := filterJSON(buffer.body.Bytes(), fields, root)
+		if err != nil {
+			buffer.flush()
+			return
+		}
+		original.Header().Del("Content-Length")
+		original.WriteHeader(http.StatusOK)
+		original.Write(filtered)
+	}
+}
+
+// parseFields parses a comma separated list of dotted field paths
+func parseFields(raw string) (fieldSet, error) {
+	paths := strings.Split(raw, ",")
+	if len(paths) > maxFields {
+		return nil, fmt.Errorf("maksimal %d field", maxFields)
+	}
+
+	fields := fieldSet{}
+	for _, path := range paths {
+		segments := strings.Split(strings.TrimSpace(path), ".")
+		for _, segment := range segments {
+			if !fieldSegmentPattern.MatchString(segment) {
+				return nil, fmt.Errorf("path field tidak valid: %q", path)
+			}
+		}
+
+		node := fields
+		for i, segment := range segments {
+			child, exists := node[segment]
+			if exists && child == nil {
+				// Field sudah diambil utuh
+				break
+			}
+			if i == len(segments)-1 {
+				node[segment] = nil
+				break
+			}
+			if !exists {
+				child = fieldSet{}
+				node[segment] = child
+			}
+			node = child
+		}
+	}
+	return fields, nil
+}
+
+// filterJSON applies fields to body, or to body[root] when root is set.
+// Urutan field mengikuti response asli.
+func filterJSON(body []byte, fields fieldSet, root string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	value, err := decodeOrdered(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	if root == "" {
+		value = fields.apply(value)
+	} else if object, ok := value.(*orderedObject); ok {
+		if data, ok := object.values[root]; ok {
+			object.values[root] = fields.apply(data)
+		}
+	}
+	return json.Marshal(value)
+}
+
+// apply keeps only the selected fields of value
+func (fields fieldSet) apply(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *orderedObject:
+		filtered := &orderedObject{values: make(map[string]interface{}, len(fields))}
+		for _, name := range v.keys {
+			sub, ok := fields[name]
+			if !ok {
+				continue
+			}
+			filtered.keys = append(filtered.keys, name)
+			if sub == nil {
+				filtered.values[name] = v.values[name]
+			} else {
+				filtered.values[name] = sub.apply(v.values[name])
+			}
+		}
+		return filtered
+	case []interface{}:
+		for i, item := range v {
+			v[i] = fields.apply(item)
+		}
+		return v
+	}
+	return value
+}
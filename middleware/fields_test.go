@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseFields(t *testing.T) {
+	fields, err := parseFields("judul, episode_list.episode,episode_list.url,details,details.status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fields["judul"]; !ok || fields["judul"] != nil {
+		t.Errorf("judul = %v, want whole field", fields["judul"])
+	}
+	if len(fields["episode_list"]) != 2 {
+		t.Errorf("episode_list = %v, want episode and url", fields["episode_list"])
+	}
+	if fields["details"] != nil {
+		t.Errorf("details = %v, want whole field", fields["details"])
+	}
+
+	for _, raw := range []string{"judul,", "a..b", "judul;drop", "."} {
+		if _, err := parseFields(raw); err == nil {
+			t.Errorf("parseFields(%q) succeeded, want error", raw)
+		}
+	}
+}
+
+func TestFieldsMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RenderErrors(JSONError))
+	detail := gin.H{
+		"judul":            "Drama",
+		"cover":            "https://dramaqu.ad/cover.jpg",
+		"confidence_score": 0.95,
+		"episode_list": []gin.H{
+			{"episode": "1", "url": "https://dramaqu.ad/drama/"},
+			{"episode": "2", "url": "https://dramaqu.ad/drama/2/"},
+		},
+	}
+	r.GET("/v1", Fields(""), func(c *gin.Context) { c.JSON(http.StatusOK, detail) })
+	r.GET("/ordered", Fields(""), func(c *gin.Context) {
+		c.JSON(http.StatusOK, struct {
+			Judul string `json:"judul"`
+			Skor  string `json:"skor"`
+			Cover string `json:"cover"`
+		}{"Drama", "8.5", "cover.jpg"})
+	})
+	r.GET("/v2", Fields("data"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"data": []gin.H{{"title": "Drama", "slug": "drama"}}, "meta": gin.H{"source": "dramaqu.ad"}})
+	})
+
+	tests := []struct {
+		path     string
+		wantCode int
+		wantBody string
+	}{
+		{"/v1?fields=judul,cover,episode_list.episode", http.StatusOK, `{"cover":"https://dramaqu.ad/cover.jpg","episode_list":[{"episode":"1"},{"episode":"2"}],"judul":"Drama"}`},
+		{"/v1?fields=confidence_score,tidak_ada", http.StatusOK, `{"confidence_score":0.95}`},
+		{"/ordered?fields=cover,judul", http.StatusOK, `{"judul":"Drama","cover":"cover.jpg"}`},
+		{"/v2?fields=title", http.StatusOK, `{"data":[{"title":"Drama"}],"meta":{"source":"dramaqu.ad"}}`},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if w.Code != tt.wantCode || w.Body.String() != tt.wantBody {
+			t.Errorf("%s: got %d %s, want %d %s", tt.path, w.Code, w.Body.String(), tt.wantCode, tt.wantBody)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1?fields=judul,,cover", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("invalid fields: got %d, want 400", w.Code)
+	}
+}
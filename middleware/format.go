@@ -0,0 +1,449 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+	"github.com/nabilulilalbab/dramaqu/apierror"
+)
+
+// Output formats supported by Format
+const (
+	FormatJSON    = "json"
+	FormatCSV     = "csv"
+	FormatNDJSON  = "ndjson"
+	FormatMsgPack = "msgpack"
+)
+
+// formatMediaTypes maps the media types of the Accept header to output formats
+var formatMediaTypes = map[string]string{
+	"application/json":      FormatJSON,
+	"text/csv":              FormatCSV,
+	"application/x-ndjson":  FormatNDJSON,
+	"application/msgpack":   FormatMsgPack,
+	"application/x-msgpack": FormatMsgPack,
+}
+
+// offeredMediaTypes is the negotiation order of the Accept header; JSON tetap default
+var offeredMediaTypes = []string{"application/json", "text/csv", "application/x-ndjson", "application/msgpack", "application/x-msgpack"}
+
+// csvArraySeparator joins array values (misalnya genre) into one CSV cell
+const csvArraySeparator = "; "
+
+// Format middleware mengubah response list JSON ke format lain sesuai ?format=
+// (json, csv, ndjson, msgpack) atau header Accept. ?format= lebih diutamakan.
+// CSV dan NDJSON berisi item dari field "data" (satu baris per item), sedangkan
+// MessagePack berisi seluruh body. Response error tetap JSON. item adalah contoh
+// elemen "data" (misalnya models.SuggestItem{}) yang kolomnya menjadi header CSV
+// saat list kosong; nil jika tidak ada.
+func Format(item interface{}) gin.HandlerFunc {
+	var template interface{}
+	if item != nil {
+		template = placeholder(reflect.TypeOf(item))
+	}
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept")
+		format, formatErr := negotiateFormat(c)
+		if formatErr != nil {
+			abortWithError(c, formatErr)
+			return
+		}
+		if format == FormatJSON {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		buffer := &bufferedWriter{ResponseWriter: original}
+		c.Writer = buffer
+		c.Next()
+		c.Writer = original
+
+		if !buffer.Written() {
+			return
+		}
+		mediaType, _, _ := mime.ParseMediaType(original.Header().Get("Content-Type"))
+		if buffer.Status() != http.StatusOK || mediaType != "application/json" {
+			buffer.flush()
+			return
+		}
+
+		var output []byte
+		var contentType string
+		var err error
+		switch format {
+		case FormatCSV:
+			output, err = listToCSV(buffer.body.Bytes(), emptyItem(c, template))
+			contentType = "text/csv; charset=utf-8"
+		case FormatNDJSON:
+			output, err = listToNDJSON(buffer.body.Bytes())
+			contentType = "application/x-ndjson"
+		case FormatMsgPack:
+			output, err = toMsgPack(buffer.body.Bytes())
+			contentType = "application/msgpack"
+		}
+		if err != nil {
+			// Body bukan list yang bisa dikonversi; kirim JSON aslinya
+			buffer.flush()
+			return
+		}
+
+		header := original.Header()
+		header.Set("Content-Type", contentType)
+		header.Del("Content-Length")
+		original.WriteHeader(http.StatusOK)
+		original.Write(output)
+	}
+}
+
+// negotiateFormat picks the output format from ?format= or the Accept header
+func negotiateFormat(c *gin.Context) (string, *apierror.Error) {
+	if format := strings.ToLower(c.Query("format")); format != "" {
+		switch format {
+		case FormatJSON, FormatCSV, FormatNDJSON, FormatMsgPack:
+			return format, nil
+		}
+		return "", apierror.InvalidParam("format", apierror.Message{
+			EN: "Format must be one of json, csv, ndjson or msgpack",
+			ID: "Format harus salah satu dari json, csv, ndjson atau msgpack",
+		}, nil)
+	}
+	// Accept yang tidak dikenal (misalnya text/html dari browser) tetap mendapat JSON
+	if format, ok := formatMediaTypes[c.NegotiateFormat(offeredMediaTypes...)]; ok {
+		return format, nil
+	}
+	return FormatJSON, nil
+}
+
+// listItems returns the raw items of the "data" array of a response body.
+// Body tanpa array "data" adalah error agar Format mengirim JSON aslinya.
+func listItems(body []byte) ([]json.RawMessage, error) {
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+	data := bytes.TrimSpace(response["data"])
+	if len(data) == 0 || data[0] != '[' {
+		return nil, errors.New("response tidak memiliki array data")
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// emptyItem returns the placeholder item of a list after ?fields= is applied.
+// Dipakai untuk header CSV dari list kosong.
+func emptyItem(c *gin.Context, template interface{}) interface{} {
+	if template == nil {
+		return nil
+	}
+	data := []interface{}{template}
+	selection, ok := c.Get(fieldSelectionKey)
+	if !ok {
+		return data[0]
+	}
+
+	// Filter sama seperti filterJSON pada body {"data": [item]}
+	body := &orderedObject{keys: []string{"data"}, values: map[string]interface{}{"data": data}}
+	var filtered interface{} = body
+	if selected := selection.(fieldSelection); selected.root == "" {
+		filtered = selected.fields.apply(body)
+	} else {
+		body.values[selected.root] = selected.fields.apply(data)
+	}
+	object, _ := filtered.(*orderedObject)
+	if object == nil {
+		return nil
+	}
+	if items, _ := object.values["data"].([]interface{}); len(items) > 0 {
+		return items[0]
+	}
+	return nil
+}
+
+// jsonMarshalerType is used to keep types with custom JSON encoding (time.Time) as one column
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// placeholder returns an *orderedObject mirroring the JSON fields of a struct
+// type, atau nil untuk tipe lain yang menjadi satu kolom
+func placeholder(t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		return nil
+	}
+
+	object := &orderedObject{values: map[string]interface{}{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		value := placeholder(field.Type)
+		if field.Anonymous && name == "" {
+			// Field embedded ditulis di level yang sama
+			if embedded, ok := value.(*orderedObject); ok {
+				for _, key := range embedded.keys {
+					object.keys = append(object.keys, key)
+					object.values[key] = embedded.values[key]
+				}
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		object.keys = append(object.keys, name)
+		object.values[name] = value
+	}
+	return object
+}
+
+// listToNDJSON writes every list item as one compact JSON line
+func listToNDJSON(body []byte) ([]byte, error) {
+	items, err := listItems(body)
+	if err != nil {
+		return nil, err
+	}
+	var output bytes.Buffer
+	for _, item := range items {
+		if err := json.Compact(&output, item); err != nil {
+			return nil, err
+		}
+		output.WriteByte('\n')
+	}
+	return output.Bytes(), nil
+}
+
+// listToCSV flattens the list items into CSV rows. Object bertingkat menjadi kolom
+// bertitik (info.status), array nilai skalar digabung dengan csvArraySeparator, dan
+// array object ditulis sebagai JSON. Kolom mengikuti urutan field pertama kali muncul;
+// list kosong tetap mendapat baris header dari kolom empty.
+func listToCSV(body []byte, empty interface{}) ([]byte, error) {
+	items, err := listItems(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []string
+	seen := map[string]bool{}
+	addColumn := func(column string) {
+		if !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
+	}
+	if len(items) == 0 && empty != nil {
+		flattenCSV("", empty, map[string]string{}, addColumn)
+	}
+	rows := make([]map[string]string, 0, len(items))
+	for _, item := range items {
+		decoder := json.NewDecoder(bytes.NewReader(item))
+		decoder.UseNumber()
+		value, err := decodeOrdered(decoder)
+		if err != nil {
+			return nil, err
+		}
+		row := map[string]string{}
+		flattenCSV("", value, row, addColumn)
+		rows = append(rows, row)
+	}
+
+	var output bytes.Buffer
+	writer := csv.NewWriter(&output)
+	if len(columns) > 0 {
+		writer.Write(columns)
+	}
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = row[column]
+		}
+		writer.Write(record)
+	}
+	writer.Flush()
+	return output.Bytes(), writer.Error()
+}
+
+// flattenCSV fills row with the cells of value under the column prefix
+func flattenCSV(prefix string, value interface{}, row map[string]string, addColumn func(string)) {
+	if object, ok := value.(*orderedObject); ok {
+		for _, key := range object.keys {
+			column := key
+			if prefix != "" {
+				column = prefix + "." + key
+			}
+			flattenCSV(column, object.values[key], row, addColumn)
+		}
+		return
+	}
+
+	column := prefix
+	if column == "" {
+		// Item list berupa nilai skalar
+		column = "value"
+	}
+	addColumn(column)
+	row[column] = csvCell(value)
+}
+
+// csvCell formats a non-object value as one CSV cell
+func csvCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			switch item.(type) {
+			case *orderedObject, []interface{}:
+				// Array object tidak bisa digabung; tulis sebagai JSON
+				encoded, _ := json.Marshal(v)
+				return string(encoded)
+			}
+			parts = append(parts, csvCell(item))
+		}
+		return strings.Join(parts, csvArraySeparator)
+	}
+	return ""
+}
+
+// orderedObject is a decoded JSON object that keeps the order of its keys
+type orderedObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// MarshalJSON encodes the object with its original key order
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	var output bytes.Buffer
+	output.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			output.WriteByte(',')
+		}
+		encodedKey, _ := json.Marshal(key)
+		output.Write(encodedKey)
+		output.WriteByte(':')
+		encodedValue, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		output.Write(encodedValue)
+	}
+	output.WriteByte('}')
+	return output.Bytes(), nil
+}
+
+// decodeOrdered decodes the next JSON value, objects as *orderedObject
+func decodeOrdered(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := &orderedObject{values: map[string]interface{}{}}
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := keyToken.(string)
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := object.values[key]; !exists {
+				object.keys = append(object.keys, key)
+			}
+			object.values[key] = value
+		}
+		_, err = decoder.Token()
+		return object, err
+	case json.Delim('['):
+		array := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err = decoder.Token()
+		return array, err
+	}
+	return token, nil
+}
+
+// toMsgPack re-encodes the whole JSON body as MessagePack
+func toMsgPack(body []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	recorder := &msgpackBuffer{header: http.Header{}}
+	if err := (render.MsgPack{Data: msgpackValue(value)}).Render(recorder); err != nil {
+		return nil, err
+	}
+	return recorder.body.Bytes(), nil
+}
+
+// msgpackValue converts json.Number to int64 or float64, karena codec MessagePack
+// akan menulisnya sebagai string
+func msgpackValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = msgpackValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = msgpackValue(item)
+		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	}
+	return value
+}
+
+// msgpackBuffer is the http.ResponseWriter render.MsgPack writes into
+type msgpackBuffer struct {
+	header http.Header
+	body   bytes.Buffer
+}
+
+func (w *msgpackBuffer) Header() http.Header { return w.header }
+
+func (w *msgpackBuffer) Write(data []byte) (int, error) { return w.body.Write(data) }
+
+func (w *msgpackBuffer) WriteHeader(int) {}
@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ugorji/go/codec"
+)
+
+// formatItem is the list item type of the test routes
+type formatItem struct {
+	Judul  string   `json:"judul"`
+	Genres []string `json:"genres"`
+	Info   struct {
+		Status string `json:"status"`
+	} `json:"info"`
+	Skor   float64 `json:"skor,omitempty"`
+	hidden string
+}
+
+func newFormatRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RenderErrors(JSONError))
+	r.GET("/movie", Format(formatItem{}), Fields(""), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"confidence_score": 1,
+			"source":           "dramaqu.ad",
+			"data": []gin.H{
+				{"judul": "Drama, Satu", "genres": []string{"Action", "Drama"}, "info": gin.H{"status": "Ongoing"}},
+				{"judul": "Drama Dua", "genres": []string{}, "skor": 8.5},
+			},
+		})
+	})
+	r.GET("/empty", Format(formatItem{}), Fields(""), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"source": "dramaqu.ad", "data": []formatItem{}})
+	})
+	r.GET("/detail", Format(formatItem{}), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"judul": "Drama Satu"})
+	})
+	r.GET("/error", Format(nil), func(c *gin.Context) {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "upstream"})
+	})
+	return r
+}
+
+func TestFormatCSV(t *testing.T) {
+	r := newFormatRouter()
+	// Kolom mengikuti urutan field pertama kali muncul (gin.H di-encode berurutan abjad)
+	want := "genres,info.status,judul,skor\n" +
+		"Action; Drama,Ongoing,\"Drama, Satu\",\n" +
+		",,Drama Dua,8.5\n"
+
+	for _, tt := range []struct {
+		path   string
+		header http.Header
+	}{
+		{"/movie?format=csv", nil},
+		{"/movie?format=CSV", http.Header{"Accept": {"application/json"}}},
+		{"/movie", http.Header{"Accept": {"text/csv"}}},
+	} {
+		w := serve(r, tt.path, tt.header)
+		if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/csv; charset=utf-8" || w.Body.String() != want {
+			t.Errorf("%s %v: got %d %q\n%s", tt.path, tt.header, w.Code, w.Header().Get("Content-Type"), w.Body.String())
+		}
+	}
+
+	w := serve(r, "/movie?format=csv&fields=data.judul", nil)
+	if want := "judul\n\"Drama, Satu\"\nDrama Dua\n"; w.Body.String() != want {
+		t.Errorf("csv with fields = %q, want %q", w.Body.String(), want)
+	}
+}
+
+func TestFormatNDJSON(t *testing.T) {
+	w := serve(newFormatRouter(), "/movie?format=ndjson&fields=data.judul", nil)
+	want := "{\"judul\":\"Drama, Satu\"}\n{\"judul\":\"Drama Dua\"}\n"
+	if w.Code != http.StatusOK || w.Body.String() != want || w.Header().Get("Content-Type") != "application/x-ndjson" {
+		t.Errorf("got %d %q %q", w.Code, w.Header().Get("Content-Type"), w.Body.String())
+	}
+}
+
+func TestFormatMsgPack(t *testing.T) {
+	w := serve(newFormatRouter(), "/movie", http.Header{"Accept": {"application/msgpack"}})
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/msgpack" {
+		t.Fatalf("got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	var decoded map[string]interface{}
+	if err := codec.NewDecoderBytes(w.Body.Bytes(), new(codec.MsgpackHandle)).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["confidence_score"] != int64(1) {
+		t.Errorf("confidence_score = %#v, want int64(1)", decoded["confidence_score"])
+	}
+	if data, ok := decoded["data"].([]interface{}); !ok || len(data) != 2 {
+		t.Errorf("data = %#v", decoded["data"])
+	}
+}
+
+func TestFormatFallbacks(t *testing.T) {
+	r := newFormatRouter()
+	if w := serve(r, "/movie?format=xml", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("unknown format: got %d, want 400", w.Code)
+	}
+	if w := serve(r, "/error?format=csv", nil); w.Code != http.StatusBadGateway || w.Body.String() != `{"error":"upstream"}` {
+		t.Errorf("error response: got %d %q", w.Code, w.Body.String())
+	}
+	w := serve(r, "/movie", http.Header{"Accept": {"text/html,application/xhtml+xml,*/*;q=0.8"}})
+	if w.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Errorf("browser Accept: Content-Type = %q, want JSON", w.Header().Get("Content-Type"))
+	}
+}
+
+func TestFormatEmptyList(t *testing.T) {
+	r := newFormatRouter()
+	for path, want := range map[string]string{
+		"/empty?format=csv":                            "judul,genres,info.status,skor\n",
+		"/empty?format=csv&fields=data.skor,data.info": "info.status,skor\n",
+		"/empty?format=ndjson":                         "",
+		"/empty?format=csv&fields=source":              `{"source":"dramaqu.ad"}`,
+	} {
+		if w := serve(r, path, nil); w.Code != http.StatusOK || w.Body.String() != want {
+			t.Errorf("%s: got %d %q, want %q", path, w.Code, w.Body.String(), want)
+		}
+	}
+}
+
+func TestFormatWithoutDataFallsBackToJSON(t *testing.T) {
+	r := newFormatRouter()
+	for _, format := range []string{"csv", "ndjson"} {
+		w := serve(r, "/detail?format="+format, nil)
+		if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/json; charset=utf-8" || w.Body.String() != `{"judul":"Drama Satu"}` {
+			t.Errorf("%s: got %d %q %q", format, w.Code, w.Header().Get("Content-Type"), w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nabilulilalbab/dramaqu/apierror"
+)
+
+// RequestTimeoutHeader lets a client (or gateway) ask for a shorter deadline
+const RequestTimeoutHeader = "X-Request-Timeout"
+
+// RequestTimeout middleware memberi deadline pada context request. Header
+// X-Request-Timeout (detik atau durasi Go, contoh "10" atau "1500ms") hanya bisa
+// memperpendek batas dari config; limit 0 berarti tanpa batas dari config.
+// Context juga otomatis dibatalkan saat client memutus koneksi.
+func RequestTimeout(limit time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := limit
+		if value := c.GetHeader(RequestTimeoutHeader); value != "" {
+			requested, ok := parseTimeout(value)
+			if !ok {
+				abortWithError(c, apierror.InvalidParam(RequestTimeoutHeader, apierror.Message{
+					EN: "Timeout must be a positive number of seconds or a duration like 1500ms",
+					ID: "Timeout harus berupa jumlah detik positif atau durasi seperti 1500ms",
+				}, nil))
+				return
+			}
+			if limit <= 0 || requested < limit {
+				timeout = requested
+			}
+		}
+
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// parseTimeout accepts seconds ("2.5") or a Go duration ("1500ms")
+func parseTimeout(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds <= 0 || seconds > (24*time.Hour).Seconds() {
+			return 0, false
+		}
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, false
+	}
+	return duration, true
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nabilulilalbab/dramaqu/apierror"
+	"github.com/nabilulilalbab/dramaqu/signer"
+)
+
+// SignedURL middleware untuk menolak link stream yang kedaluwarsa atau diubah
+func SignedURL(s *signer.Signer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := s.Verify(c.Request.URL, c.ClientIP())
+		if err == nil {
+			c.Next()
+			return
+		}
+
+		message := apierror.Message{EN: "Link signature is invalid", ID: "Tanda tangan link tidak valid"}
+		if errors.Is(err, signer.ErrExpired) {
+			message = apierror.Message{EN: "Link has expired", ID: "Link sudah kedaluwarsa"}
+		} else if errors.Is(err, signer.ErrMissingSignature) {
+			message = apierror.Message{EN: "Link is not signed", ID: "Link tidak ditandatangani"}
+		}
+
+		abortWithError(c, apierror.New(apierror.CodeForbidden, message, err))
+	}
+}
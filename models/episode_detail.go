@@ -2,23 +2,49 @@ package models
 
 // EpisodeDetailResponse represents the response structure for episode detail
 type EpisodeDetailResponse struct {
-	ConfidenceScore  float64           `json:"confidence_score"`
-	Message          string            `json:"message"`
-	Source           string            `json:"source"`
-	Title            string            `json:"title"`
-	ThumbnailURL     string            `json:"thumbnail_url"`
-	StreamingServers []StreamingServer `json:"streaming_servers"`
-	ReleaseInfo      string            `json:"release_info"`
-	DownloadLinks    DownloadLinks     `json:"download_links"`
-	Navigation       Navigation        `json:"navigation"`
-	AnimeInfo        AnimeInfo         `json:"anime_info"`
-	OtherEpisodes    []OtherEpisode    `json:"other_episodes"`
+	ConfidenceScore  float64             `json:"confidence_score"`
+	Message          string              `json:"message"`
+	Source           string              `json:"source"`
+	Title            string              `json:"title"`
+	ThumbnailURL     string              `json:"thumbnail_url"`
+	StreamingServers []StreamingServer   `json:"streaming_servers"`
+	Subtitles        []StreamSubtitle    `json:"subtitles"`
+	ReleaseInfo      string              `json:"release_info"`
+	DownloadLinks    DownloadLinks       `json:"download_links"`
+	Navigation       Navigation          `json:"navigation"`
+	AnimeInfo        AnimeInfo           `json:"anime_info"`
+	OtherEpisodes    []OtherEpisode      `json:"other_episodes"`
+	NonceStrategy    string              `json:"nonce_strategy,omitempty"`
+	Warnings         []ExtractionWarning `json:"warnings,omitempty"`
+}
+
+// ExtractionWarning describes a scraping step that failed
+type ExtractionWarning struct {
+	Code     string `json:"code"`
+	Strategy string `json:"strategy,omitempty"`
+	Message  string `json:"message"`
 }
 
 // StreamingServer represents each streaming server
 type StreamingServer struct {
+	ServerID     string `json:"server_id"`
 	ServerName   string `json:"server_name"`
+	Label        string `json:"label"`
+	Quality      string `json:"quality,omitempty"`
 	StreamingURL string `json:"streaming_url"`
+	// Resolved hanya diisi saat request memakai ?resolve=true
+	Resolved     *ResolvedStream `json:"resolved,omitempty"`
+	ResolveError string          `json:"resolve_error,omitempty"`
+	Health       ServerHealth    `json:"health"`
+}
+
+// ServerHealth represents the last background health check of a streaming server
+type ServerHealth struct {
+	Status      string `json:"status"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	LatencyMS   int64  `json:"latency_ms,omitempty"`
+	LastChecked string `json:"last_checked,omitempty"`
+	Error       string `json:"error,omitempty"`
 }
 
 // DownloadLinks represents download links organized by format and quality
@@ -0,0 +1,17 @@
+package models
+
+// GenreListResponse represents the response structure for genre list
+type GenreListResponse struct {
+	ConfidenceScore float64     `json:"confidence_score"`
+	Message         string      `json:"message"`
+	Source          string      `json:"source"`
+	Data            []GenreItem `json:"data"`
+}
+
+// GenreItem represents each genre (category) available on the site
+type GenreItem struct {
+	Name  string `json:"name"`
+	Slug  string `json:"slug"`
+	URL   string `json:"url"`
+	Count int    `json:"count"`
+}
@@ -2,13 +2,24 @@ package models
 
 // FinalResponse represents the complete API response structure
 type FinalResponse struct {
-	ConfidenceScore float64      `json:"confidence_score"`
-	Message         string       `json:"message"`
-	Source          string       `json:"source"`
-	Top10           []Top10Item  `json:"top10"`
-	NewEps          []NewEpsItem `json:"new_eps"`
-	Movies          []MovieItem  `json:"movies"`
-	JadwalRilis     JadwalRilis  `json:"jadwal_rilis"`
+	ConfidenceScore float64         `json:"confidence_score"`
+	Message         string          `json:"message"`
+	Source          string          `json:"source"`
+	Top10           []Top10Item     `json:"top10"`
+	NewEps          []NewEpsItem    `json:"new_eps"`
+	Movies          []MovieItem     `json:"movies"`
+	JadwalRilis     JadwalRilis     `json:"jadwal_rilis"`
+	Sections        []SectionStatus `json:"sections"`
+}
+
+// SectionStatus reports how one section of the home response was fetched
+type SectionStatus struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Source     string `json:"source"`
+	Items      int    `json:"items"`
+	DurationMS int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
 }
 
 // Top10Item represents a top 10 drama item
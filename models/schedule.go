@@ -2,10 +2,13 @@ package models
 
 // ReleaseScheduleResponse represents the response structure for release schedule
 type ReleaseScheduleResponse struct {
-	ConfidenceScore float64                   `json:"confidence_score"`
-	Message         string                    `json:"message"`
-	Source          string                    `json:"source"`
-	Data            map[string][]ReleaseEntry `json:"data"`
+	ConfidenceScore float64 `json:"confidence_score"`
+	Message         string  `json:"message"`
+	Source          string  `json:"source"`
+	Timezone        string  `json:"timezone"`
+	// Estimated is always true: hari dan jam rilis berasal dari perkiraan jadwal, bukan jadwal resmi
+	Estimated bool                      `json:"estimated"`
+	Data      map[string][]ReleaseEntry `json:"data"`
 }
 
 // ReleaseEntry represents each release item in the schedule
@@ -18,14 +21,19 @@ type ReleaseEntry struct {
 	Score       string   `json:"score"`
 	Genres      []string `json:"genres"`
 	ReleaseTime string   `json:"release_time"`
+	// NextAir is the next broadcast as ISO-8601 in the requested timezone
+	NextAir string `json:"next_air,omitempty"`
 }
 
 // ScheduleByDayResponse represents the response structure for schedule by specific day
 type ScheduleByDayResponse struct {
-	ConfidenceScore float64         `json:"confidence_score"`
-	Message         string          `json:"message"`
-	Source          string          `json:"source"`
-	Data            []ScheduleEntry `json:"data"`
+	ConfidenceScore float64 `json:"confidence_score"`
+	Message         string  `json:"message"`
+	Source          string  `json:"source"`
+	Timezone        string  `json:"timezone"`
+	// Estimated is always true: hari dan jam rilis berasal dari perkiraan jadwal, bukan jadwal resmi
+	Estimated bool            `json:"estimated"`
+	Data      []ScheduleEntry `json:"data"`
 }
 
 // ScheduleEntry represents each schedule item for specific day
@@ -38,4 +46,36 @@ type ScheduleEntry struct {
 	Score       string   `json:"score"`
 	Genres      []string `json:"genres"`
 	ReleaseTime string   `json:"release_time"`
+	// NextAir is the next broadcast as ISO-8601 in the requested timezone
+	NextAir string `json:"next_air,omitempty"`
+}
+
+// UpcomingResponse represents the response structure for episodes airing within the next hours
+type UpcomingResponse struct {
+	ConfidenceScore float64 `json:"confidence_score"`
+	Message         string  `json:"message"`
+	Source          string  `json:"source"`
+	Timezone        string  `json:"timezone"`
+	Hours           int     `json:"hours"`
+	// GeneratedAt is the moment countdown_seconds is measured from (ISO-8601)
+	GeneratedAt string `json:"generated_at"`
+	// Estimated is always true: air_time berasal dari perkiraan jadwal, bukan jadwal resmi
+	Estimated bool            `json:"estimated"`
+	Data      []UpcomingEntry `json:"data"`
+}
+
+// UpcomingEntry represents the next expected episode of an ongoing drama
+type UpcomingEntry struct {
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	Slug     string `json:"anime_slug"`
+	CoverURL string `json:"cover_url"`
+	// LatestEpisode and NextEpisode are 0 when the episode text has no number
+	LatestEpisode int `json:"latest_episode"`
+	NextEpisode   int `json:"next_episode"`
+	// EpisodeURL is the expected URL of NextEpisode once it is released
+	EpisodeURL       string `json:"episode_url,omitempty"`
+	ReleaseTime      string `json:"release_time"`
+	AirTime          string `json:"air_time"`
+	CountdownSeconds int64  `json:"countdown_seconds"`
 }
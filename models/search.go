@@ -21,3 +21,19 @@ type SearchDetail struct {
 	Genre    []string `json:"genre"`
 	Cover    string   `json:"cover"`
 }
+
+// SuggestResponse represents the response structure for search suggestions
+type SuggestResponse struct {
+	ConfidenceScore float64       `json:"confidence_score"`
+	Message         string        `json:"message"`
+	Source          string        `json:"source"`
+	Data            []SuggestItem `json:"data"`
+}
+
+// SuggestItem represents each title suggestion
+type SuggestItem struct {
+	Judul string `json:"judul"`
+	URL   string `json:"url"`
+	Slug  string `json:"anime_slug"`
+	Cover string `json:"cover"`
+}
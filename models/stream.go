@@ -0,0 +1,38 @@
+package models
+
+// StreamResolveResponse represents the response structure for stream resolve
+type StreamResolveResponse struct {
+	ConfidenceScore float64        `json:"confidence_score"`
+	Message         string         `json:"message"`
+	Source          string         `json:"source"`
+	Data            ResolvedStream `json:"data"`
+}
+
+// ResolvedStream represents the direct media extracted from an embed player
+type ResolvedStream struct {
+	Resolver  string            `json:"resolver"`
+	EmbedURL  string            `json:"embed_url"`
+	Sources   []MediaSource     `json:"sources"`
+	Subtitles []StreamSubtitle  `json:"subtitles"`
+	Headers   map[string]string `json:"headers"`
+	// ProxyExpiresAt adalah waktu kedaluwarsa tanda tangan proxy_url (RFC3339)
+	ProxyExpiresAt string `json:"proxy_expires_at"`
+}
+
+// MediaSource represents one direct HLS or MP4 URL
+type MediaSource struct {
+	URL     string `json:"url"`
+	Type    string `json:"type"`
+	Quality string `json:"quality,omitempty"`
+	// ProxyURL memutar source melalui /api/v1/stream/proxy dengan header yang dibutuhkan
+	ProxyURL string `json:"proxy_url"`
+}
+
+// StreamSubtitle represents one subtitle track of a resolved stream
+type StreamSubtitle struct {
+	Language string `json:"language"`
+	Label    string `json:"label,omitempty"`
+	Format   string `json:"format"`
+	URL      string `json:"url"`
+	ProxyURL string `json:"proxy_url"`
+}
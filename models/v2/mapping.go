@@ -0,0 +1,326 @@
+package v2
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nabilulilalbab/dramaqu/models"
+)
+
+// Days are the schedule keys, in week order
+var Days = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// NewConfidence builds the confidence block from a v1 score and message
+func NewConfidence(score float64, message string) *Confidence {
+	return &Confidence{Score: score, Message: message}
+}
+
+// NewPagination builds pagination for a page holding count items
+func NewPagination(page, count int) *Pagination {
+	p := &Pagination{Page: page, Count: count}
+	if page > 1 {
+		p.PrevPage = page - 1
+	}
+	if count > 0 {
+		p.NextPage = page + 1
+	}
+	return p
+}
+
+// FromDramaEntries maps the ongoing drama list
+func FromDramaEntries(items []models.DramaEntry) []Drama {
+	dramas := make([]Drama, 0, len(items))
+	for _, item := range items {
+		dramas = append(dramas, Drama{
+			Slug:          item.Slug,
+			Title:         item.Judul,
+			URL:           item.URL,
+			CoverURL:      item.Cover,
+			LatestEpisode: item.Episode,
+		})
+	}
+	return dramas
+}
+
+// FromDramaDetails maps movie and genre lists
+func FromDramaDetails(items []models.DramaDetail) []Drama {
+	dramas := make([]Drama, 0, len(items))
+	for _, item := range items {
+		dramas = append(dramas, Drama{
+			Slug:     item.Slug,
+			Title:    item.Judul,
+			URL:      item.URL,
+			CoverURL: item.Cover,
+			Status:   item.Status,
+			Score:    item.Skor,
+			Views:    item.Views,
+			Synopsis: item.Sinopsis,
+			Genres:   item.Genres,
+			Released: item.Tanggal,
+		})
+	}
+	return dramas
+}
+
+// FromSearchDetails maps search results
+func FromSearchDetails(items []models.SearchDetail) []Drama {
+	dramas := make([]Drama, 0, len(items))
+	for _, item := range items {
+		dramas = append(dramas, Drama{
+			Slug:     item.Slug,
+			Title:    item.Judul,
+			URL:      item.URL,
+			CoverURL: item.Cover,
+			Type:     item.Tipe,
+			Status:   item.Status,
+			Score:    item.Skor,
+			Views:    item.Penonton,
+			Synopsis: item.Sinopsis,
+			Genres:   item.Genre,
+		})
+	}
+	return dramas
+}
+
+// FromSuggestItems maps search suggestions
+func FromSuggestItems(items []models.SuggestItem) []Drama {
+	dramas := make([]Drama, 0, len(items))
+	for _, item := range items {
+		dramas = append(dramas, Drama{Slug: item.Slug, Title: item.Judul, URL: item.URL, CoverURL: item.Cover})
+	}
+	return dramas
+}
+
+// FromHome maps the home page response
+func FromHome(home *models.FinalResponse) Home {
+	result := Home{
+		Top:            make([]Drama, 0, len(home.Top10)),
+		LatestEpisodes: make([]Drama, 0, len(home.NewEps)),
+		Movies:         make([]Drama, 0, len(home.Movies)),
+		Schedule:       make(map[string][]ScheduleEntry, len(Days)),
+		Sections:       home.Sections,
+	}
+	for _, item := range home.Top10 {
+		result.Top = append(result.Top, Drama{
+			Slug: item.AnimeSlug, Title: item.Judul, URL: item.URL, CoverURL: item.Cover, Score: item.Rating, Genres: item.Genres,
+		})
+	}
+	for _, item := range home.NewEps {
+		result.LatestEpisodes = append(result.LatestEpisodes, Drama{
+			Slug: item.AnimeSlug, Title: item.Judul, URL: item.URL, CoverURL: item.Cover, LatestEpisode: item.Episode, Released: item.Rilis,
+		})
+	}
+	for _, item := range home.Movies {
+		result.Movies = append(result.Movies, Drama{
+			Slug: item.AnimeSlug, Title: item.Judul, URL: item.URL, CoverURL: item.Cover, Genres: item.Genres, Released: item.Tanggal,
+		})
+	}
+
+	jadwal := home.JadwalRilis
+	for i, items := range [][]models.JadwalItem{jadwal.Monday, jadwal.Tuesday, jadwal.Wednesday, jadwal.Thursday, jadwal.Friday, jadwal.Saturday, jadwal.Sunday} {
+		entries := make([]ScheduleEntry, 0, len(items))
+		for _, item := range items {
+			entries = append(entries, ScheduleEntry{
+				Drama: Drama{
+					Slug: item.AnimeSlug, Title: item.Title, URL: item.URL, CoverURL: item.CoverURL, Type: item.Type, Score: item.Score, Genres: item.Genres,
+				},
+				Day:         Days[i],
+				ReleaseTime: item.ReleaseTime,
+			})
+		}
+		result.Schedule[Days[i]] = entries
+	}
+	return result
+}
+
+// FromReleaseSchedule maps the weekly schedule, keyed by lowercase English day
+func FromReleaseSchedule(data map[string][]models.ReleaseEntry) map[string][]ScheduleEntry {
+	schedule := make(map[string][]ScheduleEntry, len(Days))
+	for _, day := range Days {
+		schedule[day] = []ScheduleEntry{}
+	}
+	for day, items := range data {
+		day = strings.ToLower(day)
+		for _, item := range items {
+			schedule[day] = append(schedule[day], ScheduleEntry{
+				Drama: Drama{
+					Slug: item.Slug, Title: item.Title, URL: item.URL, CoverURL: item.CoverURL, Type: item.Type, Score: item.Score, Genres: item.Genres,
+				},
+				Day:         day,
+				ReleaseTime: item.ReleaseTime,
+				NextAir:     item.NextAir,
+			})
+		}
+	}
+	return schedule
+}
+
+// FromScheduleEntries maps the schedule of one day
+func FromScheduleEntries(day string, items []models.ScheduleEntry) []ScheduleEntry {
+	entries := make([]ScheduleEntry, 0, len(items))
+	for _, item := range items {
+		entries = append(entries, ScheduleEntry{
+			Drama: Drama{
+				Slug: item.Slug, Title: item.Title, URL: item.URL, CoverURL: item.CoverURL, Type: item.Type, Score: item.Score, Genres: item.Genres,
+			},
+			Day:         strings.ToLower(day),
+			ReleaseTime: item.ReleaseTime,
+			NextAir:     item.NextAir,
+		})
+	}
+	return entries
+}
+
+// FromDetail maps a drama detail page
+func FromDetail(detail *models.DetailResponse) DramaDetail {
+	result := DramaDetail{
+		Drama: Drama{
+			Slug:     detail.AnimeSlug,
+			Title:    detail.Judul,
+			URL:      detail.URL,
+			CoverURL: detail.Cover,
+			Type:     detail.Tipe,
+			Status:   detail.Status,
+			Score:    detail.Skor,
+			Synopsis: detail.Sinopsis,
+			Genres:   detail.Genre,
+		},
+		Viewers: detail.Penonton,
+		Info: DramaInfo{
+			NativeTitle:   detail.Details.Japanese,
+			EnglishTitle:  detail.Details.English,
+			Status:        detail.Details.Status,
+			Type:          detail.Details.Type,
+			Source:        detail.Details.Source,
+			Duration:      detail.Details.Duration,
+			TotalEpisodes: detail.Details.TotalEpisode,
+			Season:        detail.Details.Season,
+			Studio:        detail.Details.Studio,
+			Producers:     detail.Details.Producers,
+			Released:      detail.Details.Released,
+		},
+		Rating:          Rating{Score: detail.Rating.Score, Users: detail.Rating.Users},
+		Episodes:        make([]EpisodeRef, 0, len(detail.EpisodeList)),
+		Recommendations: make([]Drama, 0, len(detail.Recommendations)),
+	}
+	for _, ep := range detail.EpisodeList {
+		result.Episodes = append(result.Episodes, EpisodeRef{
+			Number: ep.Episode, Title: ep.Title, URL: ep.URL, Slug: ep.EpisodeSlug, ReleaseDate: ep.ReleaseDate,
+		})
+	}
+	for _, rec := range detail.Recommendations {
+		result.Recommendations = append(result.Recommendations, Drama{
+			Slug: rec.AnimeSlug, Title: rec.Title, URL: rec.URL, CoverURL: rec.CoverURL, Score: rec.Rating, LatestEpisode: rec.Episode,
+		})
+	}
+	return result
+}
+
+// FromEpisodeDetail maps an episode page
+func FromEpisodeDetail(detail *models.EpisodeDetailResponse) Episode {
+	result := Episode{
+		Title:       detail.Title,
+		CoverURL:    detail.ThumbnailURL,
+		ReleaseInfo: detail.ReleaseInfo,
+		Drama: EpisodeDrama{
+			Title:    detail.AnimeInfo.Title,
+			CoverURL: detail.AnimeInfo.ThumbnailURL,
+			Synopsis: detail.AnimeInfo.Synopsis,
+			Genres:   detail.AnimeInfo.Genres,
+		},
+		Servers:   make([]Server, 0, len(detail.StreamingServers)),
+		Subtitles: detail.Subtitles,
+		Downloads: FromDownloadLinks(detail.DownloadLinks),
+		Navigation: Navigation{
+			PreviousURL:    detail.Navigation.PreviousEpisodeURL,
+			NextURL:        detail.Navigation.NextEpisodeURL,
+			AllEpisodesURL: detail.Navigation.AllEpisodesURL,
+		},
+		OtherEpisodes: make([]EpisodeRef, 0, len(detail.OtherEpisodes)),
+		Warnings:      detail.Warnings,
+	}
+	if result.Subtitles == nil {
+		result.Subtitles = []models.StreamSubtitle{}
+	}
+	if result.Warnings == nil {
+		result.Warnings = []models.ExtractionWarning{}
+	}
+	for _, server := range detail.StreamingServers {
+		result.Servers = append(result.Servers, Server{
+			ID:           server.ServerID,
+			Name:         server.ServerName,
+			Label:        server.Label,
+			Quality:      server.Quality,
+			URL:          server.StreamingURL,
+			Health:       server.Health,
+			Resolved:     server.Resolved,
+			ResolveError: server.ResolveError,
+		})
+	}
+	for _, ep := range detail.OtherEpisodes {
+		result.OtherEpisodes = append(result.OtherEpisodes, EpisodeRef{
+			Number: strings.TrimSpace(strings.TrimPrefix(ep.Title, "Episode")), Title: ep.Title, URL: ep.URL, CoverURL: ep.ThumbnailURL, ReleaseDate: ep.ReleaseDate,
+		})
+	}
+	return result
+}
+
+// FromDownloadLinks flattens download links into one list ordered by format and quality
+func FromDownloadLinks(links models.DownloadLinks) []Download {
+	downloads := []Download{}
+	formats := []struct {
+		name      string
+		byQuality map[string][]models.DownloadProvider
+	}{
+		{"mkv", links.MKV},
+		{"mp4", links.MP4},
+		{"x265", links.X265},
+	}
+	for _, format := range formats {
+		qualities := make([]string, 0, len(format.byQuality))
+		for quality := range format.byQuality {
+			qualities = append(qualities, quality)
+		}
+		sort.Slice(qualities, func(i, j int) bool {
+			a, b := qualityRank(qualities[i]), qualityRank(qualities[j])
+			if a != b {
+				return a < b
+			}
+			return qualities[i] < qualities[j]
+		})
+		for _, quality := range qualities {
+			for _, provider := range format.byQuality[quality] {
+				downloads = append(downloads, Download{Format: format.name, Quality: quality, Provider: provider.Provider, URL: provider.URL})
+			}
+		}
+	}
+	return downloads
+}
+
+// FromGenres maps the genre list
+func FromGenres(items []models.GenreItem) []Genre {
+	genres := make([]Genre, 0, len(items))
+	for _, item := range items {
+		genres = append(genres, Genre{Slug: item.Slug, Name: item.Name, URL: item.URL, Count: item.Count})
+	}
+	return genres
+}
+
+// qualityRank returns the first number in a label like "720p", or 0 when unknown
+func qualityRank(quality string) int {
+	start := strings.IndexFunc(quality, isDigit)
+	if start < 0 {
+		return 0
+	}
+	end := start
+	for end < len(quality) && isDigit(rune(quality[end])) {
+		end++
+	}
+	rank, _ := strconv.Atoi(quality[start:end])
+	return rank
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
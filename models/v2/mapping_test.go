@@ -0,0 +1,71 @@
+package v2
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nabilulilalbab/dramaqu/models"
+)
+
+func TestFromDownloadLinksOrder(t *testing.T) {
+	links := models.DownloadLinks{
+		MKV: map[string][]models.DownloadProvider{
+			"1080p": {{Provider: "Mega", URL: "https://mega.nz/file/a"}},
+			"360p":  {{Provider: "Google Drive", URL: "https://drive.google.com/b"}},
+		},
+		MP4: map[string][]models.DownloadProvider{
+			"720p": {{Provider: "Pixeldrain", URL: "https://pixeldrain.com/u/c"}},
+		},
+		X265: map[string][]models.DownloadProvider{
+			"540p": {{Provider: "Acefile", URL: "https://acefile.co/f/d"}},
+		},
+	}
+
+	want := []Download{
+		{Format: "mkv", Quality: "360p", Provider: "Google Drive", URL: "https://drive.google.com/b"},
+		{Format: "mkv", Quality: "1080p", Provider: "Mega", URL: "https://mega.nz/file/a"},
+		{Format: "mp4", Quality: "720p", Provider: "Pixeldrain", URL: "https://pixeldrain.com/u/c"},
+		{Format: "x265", Quality: "540p", Provider: "Acefile", URL: "https://acefile.co/f/d"},
+	}
+	if got := FromDownloadLinks(links); !reflect.DeepEqual(got, want) {
+		t.Errorf("FromDownloadLinks() = %+v, want %+v", got, want)
+	}
+	if got := FromDownloadLinks(models.DownloadLinks{}); got == nil || len(got) != 0 {
+		t.Errorf("FromDownloadLinks(empty) = %#v, want empty slice", got)
+	}
+}
+
+func TestFromReleaseScheduleDays(t *testing.T) {
+	schedule := FromReleaseSchedule(map[string][]models.ReleaseEntry{
+		"Monday": {{Title: "Judul", Slug: "judul", ReleaseTime: "20:00", NextAir: "2026-10-19T20:00:00+07:00"}},
+	})
+
+	if len(schedule) != len(Days) {
+		t.Fatalf("got %d days, want %d", len(schedule), len(Days))
+	}
+	for _, day := range Days {
+		if schedule[day] == nil {
+			t.Errorf("day %q is nil, want empty slice", day)
+		}
+	}
+	monday := schedule["monday"]
+	if len(monday) != 1 || monday[0].Day != "monday" || monday[0].Slug != "judul" || monday[0].ReleaseTime != "20:00" || monday[0].NextAir != "2026-10-19T20:00:00+07:00" {
+		t.Errorf("monday = %+v", monday)
+	}
+}
+
+func TestNewPagination(t *testing.T) {
+	tests := []struct {
+		page, count int
+		want        Pagination
+	}{
+		{1, 20, Pagination{Page: 1, Count: 20, NextPage: 2}},
+		{3, 20, Pagination{Page: 3, Count: 20, PrevPage: 2, NextPage: 4}},
+		{4, 0, Pagination{Page: 4, Count: 0, PrevPage: 3}},
+	}
+	for _, tt := range tests {
+		if got := NewPagination(tt.page, tt.count); *got != tt.want {
+			t.Errorf("NewPagination(%d, %d) = %+v, want %+v", tt.page, tt.count, *got, tt.want)
+		}
+	}
+}
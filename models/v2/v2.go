@@ -0,0 +1,174 @@
+// Package v2 contains the models of the /api/v2 routes: English field names,
+// one shape per concept and a standard response envelope.
+package v2
+
+import "github.com/nabilulilalbab/dramaqu/models"
+
+// Envelope wraps every /api/v2 response
+type Envelope struct {
+	Data   interface{} `json:"data"`
+	Meta   Meta        `json:"meta"`
+	Errors []Error     `json:"errors"`
+}
+
+// Meta describes where the data came from and how complete it is
+type Meta struct {
+	Source     string      `json:"source"`
+	Confidence *Confidence `json:"confidence,omitempty"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Confidence is the completeness score of scraped data
+type Confidence struct {
+	Score   float64 `json:"score"`
+	Message string  `json:"message"`
+}
+
+// Pagination describes the page of a list response.
+// NextPage hanya diisi jika halaman ini berisi data, karena total halaman tidak diketahui.
+type Pagination struct {
+	Page     int `json:"page"`
+	Count    int `json:"count"`
+	PrevPage int `json:"prev_page,omitempty"`
+	NextPage int `json:"next_page,omitempty"`
+}
+
+// Error is one problem reported in the envelope
+type Error struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Param     string `json:"param,omitempty"`
+	RequestID string `json:"request_id"`
+}
+
+// Drama is a drama or movie as it appears in lists
+type Drama struct {
+	Slug          string   `json:"slug"`
+	Title         string   `json:"title"`
+	URL           string   `json:"url"`
+	CoverURL      string   `json:"cover_url"`
+	Type          string   `json:"type,omitempty"`
+	Status        string   `json:"status,omitempty"`
+	Score         string   `json:"score,omitempty"`
+	Views         string   `json:"views,omitempty"`
+	LatestEpisode string   `json:"latest_episode,omitempty"`
+	Synopsis      string   `json:"synopsis,omitempty"`
+	Genres        []string `json:"genres,omitempty"`
+	Released      string   `json:"released,omitempty"`
+}
+
+// ScheduleEntry is a drama on the weekly release schedule
+type ScheduleEntry struct {
+	Drama
+	Day         string `json:"day"`
+	ReleaseTime string `json:"release_time"`
+	// NextAir is the next broadcast as ISO-8601, only on the schedule endpoints
+	NextAir string `json:"next_air,omitempty"`
+}
+
+// Home is the data of GET /api/v2/home
+type Home struct {
+	Top            []Drama                    `json:"top"`
+	LatestEpisodes []Drama                    `json:"latest_episodes"`
+	Movies         []Drama                    `json:"movies"`
+	Schedule       map[string][]ScheduleEntry `json:"schedule"`
+	Sections       []models.SectionStatus     `json:"sections"`
+}
+
+// DramaDetail is the data of GET /api/v2/dramas/{slug}
+type DramaDetail struct {
+	Drama
+	Viewers         string       `json:"viewers"`
+	Info            DramaInfo    `json:"info"`
+	Rating          Rating       `json:"rating"`
+	Episodes        []EpisodeRef `json:"episodes"`
+	Recommendations []Drama      `json:"recommendations"`
+}
+
+// DramaInfo is the metadata table of a drama page
+type DramaInfo struct {
+	NativeTitle   string `json:"native_title"`
+	EnglishTitle  string `json:"english_title"`
+	Status        string `json:"status"`
+	Type          string `json:"type"`
+	Source        string `json:"source"`
+	Duration      string `json:"duration"`
+	TotalEpisodes string `json:"total_episodes"`
+	Season        string `json:"season"`
+	Studio        string `json:"studio"`
+	Producers     string `json:"producers"`
+	Released      string `json:"released"`
+}
+
+// Rating is the user rating of a drama
+type Rating struct {
+	Score string `json:"score"`
+	Users string `json:"users"`
+}
+
+// EpisodeRef links to one episode of a drama
+type EpisodeRef struct {
+	Number      string `json:"number"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Slug        string `json:"slug,omitempty"`
+	CoverURL    string `json:"cover_url,omitempty"`
+	ReleaseDate string `json:"release_date"`
+}
+
+// Episode is the data of GET /api/v2/dramas/{slug}/episodes/{n}
+type Episode struct {
+	Title         string                     `json:"title"`
+	CoverURL      string                     `json:"cover_url"`
+	ReleaseInfo   string                     `json:"release_info"`
+	Drama         EpisodeDrama               `json:"drama"`
+	Servers       []Server                   `json:"servers"`
+	Subtitles     []models.StreamSubtitle    `json:"subtitles"`
+	Downloads     []Download                 `json:"downloads"`
+	Navigation    Navigation                 `json:"navigation"`
+	OtherEpisodes []EpisodeRef               `json:"other_episodes"`
+	Warnings      []models.ExtractionWarning `json:"warnings"`
+}
+
+// EpisodeDrama is the drama an episode belongs to
+type EpisodeDrama struct {
+	Title    string   `json:"title"`
+	CoverURL string   `json:"cover_url"`
+	Synopsis string   `json:"synopsis"`
+	Genres   []string `json:"genres"`
+}
+
+// Server is one streaming server (embed player) of an episode
+type Server struct {
+	ID           string                 `json:"id"`
+	Name         string                 `json:"name"`
+	Label        string                 `json:"label"`
+	Quality      string                 `json:"quality,omitempty"`
+	URL          string                 `json:"url"`
+	Health       models.ServerHealth    `json:"health"`
+	Resolved     *models.ResolvedStream `json:"resolved,omitempty"`
+	ResolveError string                 `json:"resolve_error,omitempty"`
+}
+
+// Download is one download link. Format bernilai "mkv", "mp4" atau "x265".
+type Download struct {
+	Format   string `json:"format"`
+	Quality  string `json:"quality"`
+	Provider string `json:"provider"`
+	URL      string `json:"url"`
+}
+
+// Navigation links to the neighbouring episodes
+type Navigation struct {
+	PreviousURL    string `json:"previous_url,omitempty"`
+	NextURL        string `json:"next_url,omitempty"`
+	AllEpisodesURL string `json:"all_episodes_url"`
+}
+
+// Genre is a category on the source site
+type Genre struct {
+	Slug  string `json:"slug"`
+	Name  string `json:"name"`
+	URL   string `json:"url"`
+	Count int    `json:"count"`
+}
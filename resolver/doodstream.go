@@ -0,0 +1,73 @@
+package resolver
+
+import (
+	"context"
+	"math/rand"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	reDoodPassMD5 = regexp.MustCompile(`['"](/pass_md5/[^'"]+)['"]`)
+	reDoodToken   = regexp.MustCompile(`[?&]token=([A-Za-z0-9]+)&expiry=`)
+)
+
+// DoodstreamResolver resolves DoodStream embeds through their pass_md5 endpoint
+type DoodstreamResolver struct{}
+
+// Name returns the resolver identifier
+func (r *DoodstreamResolver) Name() string { return "doodstream" }
+
+// Hosts returns the embed hostnames handled by this resolver
+func (r *DoodstreamResolver) Hosts() []string {
+	return []string{"dood.la", "dood.so", "dood.to", "dood.ws", "dood.wf", "dood.yt", "doodstream.com", "ds2play.com", "d0000d.com", "d000d.com"}
+}
+
+// Resolve fetches the embed page, calls pass_md5 and builds the tokenized MP4 link
+func (r *DoodstreamResolver) Resolve(ctx context.Context, f Fetcher, embedURL *url.URL) (*Stream, error) {
+	page, err := f.Fetch(ctx, embedURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	passPath := firstGroup(reDoodPassMD5, string(page))
+	token := firstGroup(reDoodToken, string(page))
+	if passPath == "" || token == "" {
+		return nil, ErrNoSource
+	}
+
+	// pass_md5 mengembalikan prefix URL video sebagai plain text
+	passURL := absoluteURL(embedURL, passPath)
+	base, err := f.Fetch(ctx, passURL, map[string]string{"Referer": embedURL.String()})
+	if err != nil {
+		return nil, err
+	}
+	prefix := strings.TrimSpace(string(base))
+	if !strings.HasPrefix(prefix, "http") {
+		return nil, ErrNoSource
+	}
+
+	videoURL := prefix + randomString(10) + "?token=" + token + "&expiry=" + strconv.FormatInt(time.Now().UnixMilli(), 10)
+
+	_, subtitles := extractMedia(string(page), embedURL)
+	headers := embedHeaders(embedURL)
+	headers["Referer"] = embedURL.String()
+	return &Stream{
+		Sources:   []Source{{URL: videoURL, Type: TypeMP4}},
+		Subtitles: subtitles,
+		Headers:   headers,
+	}, nil
+}
+
+// randomString returns n random alphanumeric characters, as generated by the DoodStream player
+func randomString(n int) string {
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[rand.Intn(len(alphabet))]
+	}
+	return string(b)
+}
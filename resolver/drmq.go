@@ -0,0 +1,35 @@
+package resolver
+
+import (
+	"context"
+	"net/url"
+)
+
+// DrmqResolver resolves the JWPlayer pages served by drmq.stream, the player used by dramaqu.ad
+type DrmqResolver struct {
+	// Referer is the site the player is embedded on, contoh https://dramaqu.ad/
+	Referer string
+}
+
+// Name returns the resolver identifier
+func (r *DrmqResolver) Name() string { return "drmq" }
+
+// Hosts returns the embed hostnames handled by this resolver
+func (r *DrmqResolver) Hosts() []string { return []string{"drmq.stream"} }
+
+// Resolve fetches the embed page and extracts the JWPlayer sources and tracks
+func (r *DrmqResolver) Resolve(ctx context.Context, f Fetcher, embedURL *url.URL) (*Stream, error) {
+	// Halaman player hanya mau dibuka dari situs sumber
+	page, err := f.Fetch(ctx, embedURL.String(), map[string]string{"Referer": r.Referer})
+	if err != nil {
+		return nil, err
+	}
+
+	content := string(page) + "\n" + unpackScripts(string(page))
+	sources, subtitles := extractMedia(content, embedURL)
+	return &Stream{
+		Sources:   sources,
+		Subtitles: subtitles,
+		Headers:   embedHeaders(embedURL),
+	}, nil
+}
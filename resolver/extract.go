@@ -0,0 +1,236 @@
+package resolver
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var (
+	// reJSObject matches flat JavaScript/JSON objects, e.g. {file:"...",label:"720p"}
+	reJSObject  = regexp.MustCompile(`\{[^{}]*\}`)
+	reFileKey   = regexp.MustCompile(`["']?(?:file|src)["']?\s*:\s*["']([^"']+)["']`)
+	reLabelKey  = regexp.MustCompile(`["']?label["']?\s*:\s*["']([^"']*)["']`)
+	reKindKey   = regexp.MustCompile(`["']?kind["']?\s*:\s*["']([^"']*)["']`)
+	reLangKey   = regexp.MustCompile(`["']?(?:srclang|language|lang)["']?\s*:\s*["']([^"']*)["']`)
+	reMediaURL  = regexp.MustCompile(`https?:\\?/\\?/[^"'\s<>]+?\.(?:m3u8|mp4)(?:\?[^"'\s<>]*)?`)
+	reQuality   = regexp.MustCompile(`(?i)(2160|1440|1080|720|540|480|360|240)p?`)
+	rePacked    = regexp.MustCompile(`}\('((?:\\'|[^'])*)',\s*(\d+),\s*(\d+),\s*'((?:\\'|[^'])*)'\.split\('\|'\)`)
+	rePackedTok = regexp.MustCompile(`\b\w+\b`)
+)
+
+// languageCodes maps subtitle labels to ISO 639-1 codes
+var languageCodes = map[string]string{
+	"indonesia":  "id",
+	"indonesian": "id",
+	"bahasa":     "id",
+	"ind":        "id",
+	"id":         "id",
+	"english":    "en",
+	"eng":        "en",
+	"en":         "en",
+	"korean":     "ko",
+	"korea":      "ko",
+	"ko":         "ko",
+	"malay":      "ms",
+	"melayu":     "ms",
+	"ms":         "ms",
+}
+
+// extractMedia finds player sources and subtitle tracks in an embed page.
+// Mendukung konfigurasi JWPlayer/Plyr (objek {file:...}) dan tag <source>/<track>.
+func extractMedia(page string, base *url.URL) ([]Source, []Subtitle) {
+	var sources []Source
+	var subtitles []Subtitle
+	seen := make(map[string]bool)
+
+	addSource := func(rawURL, label string) {
+		absolute := absoluteURL(base, rawURL)
+		if absolute == "" || seen[absolute] {
+			return
+		}
+		seen[absolute] = true
+		sources = append(sources, Source{URL: absolute, Type: sourceType(absolute), Quality: qualityLabel(label, absolute)})
+	}
+	addSubtitle := func(rawURL, label, lang string) {
+		absolute := absoluteURL(base, rawURL)
+		if absolute == "" || seen[absolute] {
+			return
+		}
+		seen[absolute] = true
+		subtitles = append(subtitles, Subtitle{
+			Language: languageCode(lang, label),
+			Label:    label,
+			Format:   subtitleFormat(absolute),
+			URL:      absolute,
+		})
+	}
+
+	for _, obj := range reJSObject.FindAllString(page, -1) {
+		m := reFileKey.FindStringSubmatch(obj)
+		if m == nil {
+			continue
+		}
+		label := firstGroup(reLabelKey, obj)
+		kind := strings.ToLower(firstGroup(reKindKey, obj))
+		switch {
+		case kind == "captions" || kind == "subtitles" || subtitleFormat(m[1]) != "":
+			addSubtitle(m[1], label, firstGroup(reLangKey, obj))
+		case kind == "thumbnails" || isImage(m[1]):
+		default:
+			addSource(m[1], label)
+		}
+	}
+
+	if doc, err := goquery.NewDocumentFromReader(strings.NewReader(page)); err == nil {
+		doc.Find("video source[src], source[src]").Each(func(_ int, s *goquery.Selection) {
+			addSource(s.AttrOr("src", ""), s.AttrOr("label", s.AttrOr("size", "")))
+		})
+		doc.Find("track[src]").Each(func(_ int, s *goquery.Selection) {
+			kind := s.AttrOr("kind", "subtitles")
+			if kind == "subtitles" || kind == "captions" {
+				addSubtitle(s.AttrOr("src", ""), s.AttrOr("label", ""), s.AttrOr("srclang", ""))
+			}
+		})
+	}
+
+	// Fallback: URL media yang muncul di mana saja di dalam script
+	if len(sources) == 0 {
+		for _, rawURL := range reMediaURL.FindAllString(page, -1) {
+			addSource(rawURL, "")
+		}
+	}
+
+	return sources, subtitles
+}
+
+// unpackScripts decodes every Dean Edwards "p,a,c,k,e,d" packed script in a page
+func unpackScripts(page string) string {
+	var unpacked []string
+	for _, m := range rePacked.FindAllStringSubmatch(page, -1) {
+		payload := strings.ReplaceAll(m[1], `\'`, `'`)
+		radix, _ := strconv.Atoi(m[2])
+		count, _ := strconv.Atoi(m[3])
+		keywords := strings.Split(m[4], "|")
+		if radix < 2 || radix > 62 || len(keywords) < count {
+			continue
+		}
+		unpacked = append(unpacked, rePackedTok.ReplaceAllStringFunc(payload, func(word string) string {
+			index, ok := decodeBase(word, radix)
+			if !ok || index >= len(keywords) || keywords[index] == "" {
+				return word
+			}
+			return keywords[index]
+		}))
+	}
+	return strings.Join(unpacked, "\n")
+}
+
+// decodeBase decodes a packer word using the 0-9a-zA-Z alphabet
+func decodeBase(word string, radix int) (int, bool) {
+	const alphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	value := 0
+	for _, r := range word {
+		digit := strings.IndexRune(alphabet, r)
+		if digit < 0 || digit >= radix {
+			return 0, false
+		}
+		value = value*radix + digit
+	}
+	return value, true
+}
+
+// absoluteURL resolves rawURL against base and unescapes JSON-escaped slashes
+func absoluteURL(base *url.URL, rawURL string) string {
+	rawURL = strings.TrimSpace(strings.ReplaceAll(rawURL, `\/`, "/"))
+	if rawURL == "" || strings.HasPrefix(rawURL, "data:") || strings.HasPrefix(rawURL, "blob:") {
+		return ""
+	}
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	resolved := base.ResolveReference(parsedURL)
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return ""
+	}
+	return resolved.String()
+}
+
+// sourceType returns hls for m3u8 playlists and mp4 otherwise
+func sourceType(rawURL string) string {
+	if strings.Contains(strings.ToLower(rawURL), ".m3u8") {
+		return TypeHLS
+	}
+	return TypeMP4
+}
+
+// subtitleFormat returns vtt, srt or ass based on the file extension
+func subtitleFormat(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	switch ext := strings.ToLower(path.Ext(parsedURL.Path)); ext {
+	case ".vtt", ".srt", ".ass", ".ssa":
+		return strings.TrimPrefix(ext, ".")
+	}
+	return ""
+}
+
+// isImage reports whether rawURL points to an image (poster or thumbnail sprite)
+func isImage(rawURL string) bool {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(path.Ext(parsedURL.Path)) {
+	case ".jpg", ".jpeg", ".png", ".webp", ".gif":
+		return true
+	}
+	return false
+}
+
+// qualityLabel extracts a quality like "720p" from a source label or URL
+func qualityLabel(label, rawURL string) string {
+	for _, text := range []string{label, rawURL} {
+		if m := reQuality.FindStringSubmatch(text); m != nil {
+			return m[1] + "p"
+		}
+	}
+	return ""
+}
+
+// languageCode maps a subtitle language or label to an ISO 639-1 code
+func languageCode(lang, label string) string {
+	for _, candidate := range []string{lang, label} {
+		candidate = strings.ToLower(strings.TrimSpace(candidate))
+		if candidate == "" {
+			continue
+		}
+		if code, ok := languageCodes[candidate]; ok {
+			return code
+		}
+		for _, word := range strings.Fields(candidate) {
+			if code, ok := languageCodes[word]; ok {
+				return code
+			}
+		}
+	}
+	if lang != "" {
+		return strings.ToLower(lang)
+	}
+	return "und"
+}
+
+// firstGroup returns the first capture group of re in s, or an empty string
+func firstGroup(re *regexp.Regexp, s string) string {
+	if m := re.FindStringSubmatch(s); len(m) > 1 {
+		return m[1]
+	}
+	return ""
+}
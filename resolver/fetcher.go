@@ -0,0 +1,51 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/nabilulilalbab/dramaqu/urlguard"
+)
+
+// userAgent matches the browser user agent used by the scraping services
+const userAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.0.0 Safari/537.36"
+
+// maxPageSize limits how much of an embed page is read into memory
+const maxPageSize = 5 << 20
+
+// HTTPFetcher fetches embed pages over HTTP
+type HTTPFetcher struct {
+	client *http.Client
+}
+
+// NewHTTPFetcher creates an HTTPFetcher with the given request timeout.
+// Client hanya dapat menjangkau alamat publik, termasuk setelah redirect.
+func NewHTTPFetcher(timeout time.Duration) *HTTPFetcher {
+	return &HTTPFetcher{client: urlguard.NewClient(timeout)}
+}
+
+// Fetch performs a GET request and returns the response body
+func (f *HTTPFetcher) Fetch(ctx context.Context, rawURL string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d dari %s", resp.StatusCode, rawURL)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxPageSize))
+}
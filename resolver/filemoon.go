@@ -0,0 +1,36 @@
+package resolver
+
+import (
+	"context"
+	"net/url"
+)
+
+// FilemoonResolver resolves Filemoon embeds, whose player config is hidden in a packed script
+type FilemoonResolver struct{}
+
+// Name returns the resolver identifier
+func (r *FilemoonResolver) Name() string { return "filemoon" }
+
+// Hosts returns the embed hostnames handled by this resolver
+func (r *FilemoonResolver) Hosts() []string {
+	return []string{"filemoon.sx", "filemoon.to", "filemoon.in", "kerapoxy.cc", "moonmov.pro"}
+}
+
+// Resolve fetches the embed page, unpacks the player script and extracts the HLS sources
+func (r *FilemoonResolver) Resolve(ctx context.Context, f Fetcher, embedURL *url.URL) (*Stream, error) {
+	page, err := f.Fetch(ctx, embedURL.String(), map[string]string{"Referer": embedURL.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	unpacked := unpackScripts(string(page))
+	if unpacked == "" {
+		return nil, ErrNoSource
+	}
+	sources, subtitles := extractMedia(unpacked, embedURL)
+	return &Stream{
+		Sources:   sources,
+		Subtitles: subtitles,
+		Headers:   embedHeaders(embedURL),
+	}, nil
+}
@@ -0,0 +1,177 @@
+// Package resolver mengubah URL iframe player (embed) menjadi URL media langsung
+// (HLS/MP4) yang dapat diputar oleh player native. Setiap host embed memiliki
+// resolver sendiri yang didaftarkan ke Registry berdasarkan hostname.
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// ErrUnsupportedHost is returned when no resolver is registered for an embed host
+var ErrUnsupportedHost = errors.New("resolver: embed host tidak didukung")
+
+// ErrNoSource is returned when an embed page does not contain any playable source
+var ErrNoSource = errors.New("resolver: sumber video tidak ditemukan di halaman embed")
+
+// Source types
+const (
+	TypeHLS = "hls"
+	TypeMP4 = "mp4"
+)
+
+// Stream is the direct media extracted from an embed page
+type Stream struct {
+	Resolver  string
+	EmbedURL  string
+	Sources   []Source
+	Subtitles []Subtitle
+	// Headers harus dikirim saat mengambil media (contoh: Referer, Origin)
+	Headers map[string]string
+}
+
+// Source represents one playable media URL
+type Source struct {
+	URL     string
+	Type    string
+	Quality string
+}
+
+// Subtitle represents one subtitle track
+type Subtitle struct {
+	Language string
+	Label    string
+	Format   string
+	URL      string
+}
+
+// Fetcher downloads embed pages and auxiliary resources
+type Fetcher interface {
+	Fetch(ctx context.Context, rawURL string, headers map[string]string) ([]byte, error)
+}
+
+// Resolver extracts direct media from the embed pages of one or more hosts
+type Resolver interface {
+	// Name returns the resolver identifier shown in responses
+	Name() string
+	// Hosts returns the embed hostnames handled by this resolver
+	Hosts() []string
+	// Resolve fetches the embed page and extracts its media sources
+	Resolve(ctx context.Context, f Fetcher, embedURL *url.URL) (*Stream, error)
+}
+
+// Registry maps embed hostnames to resolvers
+type Registry struct {
+	mu        sync.RWMutex
+	fetcher   Fetcher
+	resolvers map[string]Resolver
+}
+
+// NewRegistry creates a Registry with the given resolvers registered
+func NewRegistry(fetcher Fetcher, resolvers ...Resolver) *Registry {
+	r := &Registry{
+		fetcher:   fetcher,
+		resolvers: make(map[string]Resolver),
+	}
+	for _, res := range resolvers {
+		r.Register(res)
+	}
+	return r
+}
+
+// Register adds a resolver for all of its hosts, replacing existing ones
+func (r *Registry) Register(res Resolver) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, host := range res.Hosts() {
+		r.resolvers[normalizeHost(host)] = res
+	}
+}
+
+// Lookup returns the resolver for a hostname. Subdomain juga cocok dengan
+// resolver milik domain induknya, contoh "cdn.filemoon.sx" → "filemoon.sx".
+func (r *Registry) Lookup(host string) (Resolver, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	host = normalizeHost(host)
+	for host != "" {
+		if res, ok := r.resolvers[host]; ok {
+			return res, true
+		}
+		dot := strings.Index(host, ".")
+		if dot < 0 || !strings.Contains(host[dot+1:], ".") {
+			break
+		}
+		host = host[dot+1:]
+	}
+	return nil, false
+}
+
+// Hosts returns every registered hostname
+func (r *Registry) Hosts() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	hosts := make([]string, 0, len(r.resolvers))
+	for host := range r.resolvers {
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// Resolve resolves an embed URL with the resolver registered for its host
+func (r *Registry) Resolve(ctx context.Context, embedURL string) (*Stream, error) {
+	parsedURL, err := url.Parse(embedURL)
+	if err != nil || parsedURL.Host == "" {
+		return nil, fmt.Errorf("resolver: URL embed tidak valid: %q", embedURL)
+	}
+	if parsedURL.Scheme == "" {
+		parsedURL.Scheme = "https"
+	}
+
+	res, ok := r.Lookup(parsedURL.Hostname())
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedHost, parsedURL.Hostname())
+	}
+
+	stream, err := res.Resolve(ctx, r.fetcher, parsedURL)
+	if err != nil {
+		return nil, fmt.Errorf("resolver %s: %w", res.Name(), err)
+	}
+	if len(stream.Sources) == 0 {
+		return nil, fmt.Errorf("resolver %s: %w", res.Name(), ErrNoSource)
+	}
+	stream.Resolver = res.Name()
+	stream.EmbedURL = parsedURL.String()
+	return stream, nil
+}
+
+// Builtins returns the resolvers shipped with the API.
+// siteReferer adalah Referer situs sumber untuk player yang hanya mau dibuka dari sana.
+func Builtins(siteReferer string) []Resolver {
+	return []Resolver{
+		&DrmqResolver{Referer: siteReferer},
+		&StreamtapeResolver{},
+		&DoodstreamResolver{},
+		&FilemoonResolver{},
+	}
+}
+
+// normalizeHost lowercases a hostname and strips the www. prefix
+func normalizeHost(host string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(host)), "www.")
+}
+
+// embedHeaders returns the headers most embed CDNs require
+func embedHeaders(embedURL *url.URL) map[string]string {
+	origin := embedURL.Scheme + "://" + embedURL.Host
+	return map[string]string{
+		"Referer":    origin + "/",
+		"Origin":     origin,
+		"User-Agent": userAgent,
+	}
+}
@@ -0,0 +1,157 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+// fixtureFetcher serves saved embed pages from testdata instead of the network
+type fixtureFetcher struct {
+	t     *testing.T
+	pages map[string]string
+	calls []fetchCall
+}
+
+type fetchCall struct {
+	url     string
+	headers map[string]string
+}
+
+func (f *fixtureFetcher) Fetch(_ context.Context, rawURL string, headers map[string]string) ([]byte, error) {
+	f.calls = append(f.calls, fetchCall{url: rawURL, headers: headers})
+	name, ok := f.pages[rawURL]
+	if !ok {
+		return nil, fmt.Errorf("unexpected fetch %s", rawURL)
+	}
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		f.t.Fatalf("failed to read fixture %s: %v", name, err)
+	}
+	return data, nil
+}
+
+func TestDrmqResolver(t *testing.T) {
+	f := &fixtureFetcher{t: t, pages: map[string]string{
+		"https://drmq.stream/hi/drive.php?id=abc": "drmq_embed.html",
+	}}
+	stream, err := NewRegistry(f, Builtins("https://dramaqu.ad/")...).Resolve(context.Background(), "https://drmq.stream/hi/drive.php?id=abc")
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+
+	if stream.Resolver != "drmq" {
+		t.Errorf("resolver = %q, want drmq", stream.Resolver)
+	}
+	wantSources := []Source{
+		{URL: "https://drmq.stream/hls/lovetaketwo-02/720/index.m3u8", Type: TypeHLS, Quality: "720p"},
+		{URL: "https://drmq.stream/hls/lovetaketwo-02/480/index.m3u8", Type: TypeHLS, Quality: "480p"},
+	}
+	if len(stream.Sources) != len(wantSources) {
+		t.Fatalf("sources = %+v, want %+v", stream.Sources, wantSources)
+	}
+	for i := range wantSources {
+		if stream.Sources[i] != wantSources[i] {
+			t.Errorf("source %d = %+v, want %+v", i, stream.Sources[i], wantSources[i])
+		}
+	}
+
+	wantSubtitle := Subtitle{Language: "id", Label: "Indonesia", Format: "srt", URL: "https://drmq.stream/sub/lovetaketwo-02.srt"}
+	if len(stream.Subtitles) != 1 || stream.Subtitles[0] != wantSubtitle {
+		t.Errorf("subtitles = %+v, want [%+v]", stream.Subtitles, wantSubtitle)
+	}
+	if f.calls[0].headers["Referer"] != "https://dramaqu.ad/" {
+		t.Errorf("embed page must be fetched with dramaqu.ad referer, got %q", f.calls[0].headers["Referer"])
+	}
+	if stream.Headers["Referer"] != "https://drmq.stream/" {
+		t.Errorf("stream Referer header = %q", stream.Headers["Referer"])
+	}
+}
+
+func TestFilemoonResolverUnpacksPlayerScript(t *testing.T) {
+	f := &fixtureFetcher{t: t, pages: map[string]string{
+		"https://filemoon.sx/e/abc123": "filemoon_embed.html",
+	}}
+	stream, err := NewRegistry(f, Builtins("https://dramaqu.ad/")...).Resolve(context.Background(), "https://filemoon.sx/e/abc123")
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+
+	if len(stream.Sources) != 1 {
+		t.Fatalf("sources = %+v, want one HLS source", stream.Sources)
+	}
+	want := "https://be6721.rcr72.waw04.cdn112.com/hls2/01/05521/abc123_,l,n,.urlset/master.m3u8?t=Xyz9&s=1722&e=10800"
+	if stream.Sources[0].URL != want || stream.Sources[0].Type != TypeHLS {
+		t.Errorf("source = %+v, want HLS %s", stream.Sources[0], want)
+	}
+	if len(stream.Subtitles) != 1 || stream.Subtitles[0].Language != "id" || stream.Subtitles[0].Format != "vtt" {
+		t.Errorf("subtitles = %+v, want one Indonesian vtt track", stream.Subtitles)
+	}
+	if stream.Headers["Origin"] != "https://filemoon.sx" {
+		t.Errorf("Origin header = %q", stream.Headers["Origin"])
+	}
+}
+
+func TestStreamtapeResolverUsesRobotlink(t *testing.T) {
+	f := &fixtureFetcher{t: t, pages: map[string]string{
+		"https://streamtape.com/e/Lk8x9": "streamtape_embed.html",
+	}}
+	stream, err := NewRegistry(f, Builtins("https://dramaqu.ad/")...).Resolve(context.Background(), "https://streamtape.com/e/Lk8x9")
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+
+	want := "https://streamtape.com/get_video?id=Lk8x9&expires=1724400000&ip=GxMsD0&token=Q9sL-1kD&stream=1"
+	if len(stream.Sources) != 1 || stream.Sources[0].URL != want {
+		t.Fatalf("sources = %+v, want %s", stream.Sources, want)
+	}
+	if len(stream.Subtitles) != 1 || stream.Subtitles[0].Language != "id" {
+		t.Errorf("subtitles = %+v, want Indonesian track", stream.Subtitles)
+	}
+}
+
+func TestDoodstreamResolverCallsPassMD5(t *testing.T) {
+	f := &fixtureFetcher{t: t, pages: map[string]string{
+		"https://www.dood.la/e/q7w8e9r0t1":                                "doodstream_embed.html",
+		"https://www.dood.la/pass_md5/1724400000-47-99-abcdef/q7w8e9r0t1": "doodstream_pass_md5.txt",
+	}}
+	stream, err := NewRegistry(f, Builtins("https://dramaqu.ad/")...).Resolve(context.Background(), "https://www.dood.la/e/q7w8e9r0t1")
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+
+	if len(stream.Sources) != 1 {
+		t.Fatalf("sources = %+v, want one MP4 source", stream.Sources)
+	}
+	got := stream.Sources[0].URL
+	prefix := "https://ab12cd.cloudatacdn.com/u5kj6zbhgbyl3dq7hkxrxzrbwnalz/xzyl3p2qo~"
+	if !strings.HasPrefix(got, prefix) || !strings.Contains(got, "?token=k3l4m5n6o7p8&expiry=") {
+		t.Errorf("source URL = %q, want pass_md5 prefix with token", got)
+	}
+	if f.calls[1].headers["Referer"] != "https://www.dood.la/e/q7w8e9r0t1" {
+		t.Errorf("pass_md5 must be requested with the embed page as referer, got %q", f.calls[1].headers["Referer"])
+	}
+}
+
+func TestRegistryLookup(t *testing.T) {
+	r := NewRegistry(&fixtureFetcher{t: t}, Builtins("https://dramaqu.ad/")...)
+
+	for _, host := range []string{"drmq.stream", "WWW.DRMQ.STREAM", "cdn.filemoon.sx", "dood.la"} {
+		if _, ok := r.Lookup(host); !ok {
+			t.Errorf("Lookup(%q) found no resolver", host)
+		}
+	}
+	for _, host := range []string{"evil.com", "stream", "drmq.stream.evil.com"} {
+		if _, ok := r.Lookup(host); ok {
+			t.Errorf("Lookup(%q) unexpectedly found a resolver", host)
+		}
+	}
+
+	_, err := r.Resolve(context.Background(), "https://unknown.example/embed/1")
+	if !errors.Is(err, ErrUnsupportedHost) {
+		t.Errorf("Resolve unknown host error = %v, want ErrUnsupportedHost", err)
+	}
+}
@@ -0,0 +1,65 @@
+package resolver
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// reStreamtapeLink matches the obfuscated robotlink assignment, e.g.
+// document.getElementById('robotlink').innerHTML = '//streamtape.com/get_video?id=..&token=' + ('xyzabc').substring(1).substring(2);
+var reStreamtapeLink = regexp.MustCompile(`getElementById\(['"]robotlink['"]\)\.innerHTML\s*=\s*['"]([^'"]+)['"]\s*\+\s*\(?['"]([^'"]+)['"]\)?((?:\.substring\(\d+\))+)`)
+
+var reSubstring = regexp.MustCompile(`\.substring\((\d+)\)`)
+
+// StreamtapeResolver resolves Streamtape embeds into their get_video MP4 link
+type StreamtapeResolver struct{}
+
+// Name returns the resolver identifier
+func (r *StreamtapeResolver) Name() string { return "streamtape" }
+
+// Hosts returns the embed hostnames handled by this resolver
+func (r *StreamtapeResolver) Hosts() []string {
+	return []string{"streamtape.com", "streamtape.net", "streamtape.to", "strtape.cloud", "streamta.pe"}
+}
+
+// Resolve fetches the embed page and rebuilds the obfuscated video link
+func (r *StreamtapeResolver) Resolve(ctx context.Context, f Fetcher, embedURL *url.URL) (*Stream, error) {
+	page, err := f.Fetch(ctx, embedURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Halaman berisi beberapa assignment palsu; yang terakhir adalah yang dipakai player
+	matches := reStreamtapeLink.FindAllStringSubmatch(string(page), -1)
+	if len(matches) == 0 {
+		return nil, ErrNoSource
+	}
+	m := matches[len(matches)-1]
+
+	suffix := m[2]
+	for _, sub := range reSubstring.FindAllStringSubmatch(m[3], -1) {
+		n, _ := strconv.Atoi(sub[1])
+		if n > len(suffix) {
+			return nil, ErrNoSource
+		}
+		suffix = suffix[n:]
+	}
+
+	videoURL := absoluteURL(embedURL, m[1]+suffix)
+	if videoURL == "" {
+		return nil, ErrNoSource
+	}
+	if !strings.Contains(videoURL, "stream=") {
+		videoURL += "&stream=1"
+	}
+
+	_, subtitles := extractMedia(string(page), embedURL)
+	return &Stream{
+		Sources:   []Source{{URL: videoURL, Type: TypeMP4}},
+		Subtitles: subtitles,
+		Headers:   embedHeaders(embedURL),
+	}, nil
+}
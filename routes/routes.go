@@ -1,36 +1,102 @@
 package routes
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/nabilulilalbab/dramaqu/config"
 	"github.com/nabilulilalbab/dramaqu/handlers"
+	"github.com/nabilulilalbab/dramaqu/middleware"
+	"github.com/nabilulilalbab/dramaqu/models"
+	modelsv2 "github.com/nabilulilalbab/dramaqu/models/v2"
+	"github.com/nabilulilalbab/dramaqu/signer"
 )
 
 // SetupRoutes configures all the routes for the application
-func SetupRoutes(r *gin.Engine, homeHandler *handlers.HomeHandler, animeTerbaruHandler *handlers.AnimeTerbaruHandler, movieHandler *handlers.MovieHandler, scheduleHandler *handlers.ScheduleHandler, searchHandler *handlers.SearchHandler, detailHandler *handlers.DetailHandler, episodeDetailHandler *handlers.EpisodeDetailHandler) {
+func SetupRoutes(r *gin.Engine, homeHandler *handlers.HomeHandler, animeTerbaruHandler *handlers.AnimeTerbaruHandler, movieHandler *handlers.MovieHandler, scheduleHandler *handlers.ScheduleHandler, searchHandler *handlers.SearchHandler, detailHandler *handlers.DetailHandler, episodeDetailHandler *handlers.EpisodeDetailHandler, genreHandler *handlers.GenreHandler, streamHandler *handlers.StreamHandler, subtitleHandler *handlers.SubtitleHandler, v2Handler *handlers.V2Handler, feedHandler *handlers.FeedHandler, streamSigner *signer.Signer, requestTimeout time.Duration, cache config.CacheConfig) {
+	// cached applies the HTTP cache policy of an endpoint group
+	cached := func(policy config.CachePolicy) gin.HandlerFunc {
+		return middleware.Cache(policy.MaxAge, policy.StaleWhileRevalidate)
+	}
+
+	// ?fields= sparse fieldset; v2 hanya memfilter isi "data" di dalam envelope.
+	// Dipasang setelah cached agar ETag dihitung dari body yang sudah difilter.
+	fields := middleware.Fields("")
+	fieldsV2 := middleware.Fields("data")
+	// Endpoint list juga tersedia sebagai CSV, NDJSON atau MessagePack (?format= / Accept);
+	// argumennya adalah tipe elemen list untuk header CSV saat list kosong
+	formats := middleware.Format
+
+	// Setiap request diberi ID yang disertakan di response error dan log
+	r.Use(middleware.RequestID())
+
 	// API v1 routes
-	v1 := r.Group("/api/v1")
+	v1 := r.Group("/api/v1", middleware.RenderErrors(middleware.JSONError))
+	// Endpoint scraping dikompres dan dibatasi deadline per request; proxy stream tidak,
+	// karena segmen video bisa lama dan sudah terkompresi
+	api := v1.Group("", middleware.Compress(), middleware.RequestTimeout(requestTimeout))
 	{
 		// Home endpoint
-		v1.GET("/home", homeHandler.GetHome)
+		api.GET("/home", cached(cache.Home), fields, homeHandler.GetHome)
 
 		// Anime terbaru endpoint
-		v1.GET("/anime-terbaru", animeTerbaruHandler.GetAnimeTerbaru)
+		api.GET("/anime-terbaru", cached(cache.Lists), formats(models.DramaEntry{}), fields, animeTerbaruHandler.GetAnimeTerbaru)
 
 		// Movie endpoint
-		v1.GET("/movie", movieHandler.GetMovies)
+		api.GET("/movie", cached(cache.Lists), formats(models.DramaDetail{}), fields, movieHandler.GetMovies)
 
 		// Jadwal rilis endpoint
-		v1.GET("/jadwal-rilis", scheduleHandler.GetReleaseSchedule)
-		v1.GET("/jadwal-rilis/:day", scheduleHandler.GetScheduleByDay)
+		api.GET("/jadwal-rilis", cached(cache.Schedule), fields, scheduleHandler.GetReleaseSchedule)
+		api.GET("/jadwal-rilis.ics", cached(cache.Schedule), scheduleHandler.GetScheduleCalendar)
+		api.GET("/jadwal-rilis/:day", cached(cache.Schedule), formats(models.ScheduleEntry{}), fields, scheduleHandler.GetScheduleByDay)
+		api.GET("/schedule/upcoming", cached(cache.Upcoming), formats(models.UpcomingEntry{}), fields, scheduleHandler.GetUpcoming)
 
 		// Search endpoint
-		v1.GET("/search", searchHandler.SearchDrama)
+		api.GET("/search", cached(cache.Search), formats(models.SearchDetail{}), fields, searchHandler.SearchDrama)
+		api.GET("/search/suggest", cached(cache.Search), formats(models.SuggestItem{}), fields, searchHandler.Suggest)
 
 		// Detail endpoint
-		v1.GET("/anime-detail", detailHandler.GetAnimeDetail)
+		api.GET("/anime-detail", cached(cache.Detail), fields, detailHandler.GetAnimeDetail)
 
 		// Episode Detail endpoint
-		v1.GET("/episode-detail", episodeDetailHandler.GetEpisodeDetail)
+		api.GET("/episode-detail", cached(cache.Episode), fields, episodeDetailHandler.GetEpisodeDetail)
+		api.GET("/dramas/:slug/episodes/:n", cached(cache.Episode), fields, episodeDetailHandler.GetEpisodeBySlug)
+
+		// Genre endpoints
+		api.GET("/genres", cached(cache.Genres), formats(models.GenreItem{}), fields, genreHandler.GetGenres)
+		api.GET("/genres/:slug", cached(cache.Lists), formats(models.DramaDetail{}), fields, genreHandler.GetDramasByGenre)
+
+		// Stream endpoints
+		api.GET("/stream/resolve", fields, streamHandler.Resolve)
+		v1.GET("/stream/proxy", middleware.SignedURL(streamSigner), streamHandler.Proxy)
+
+		// Subtitle endpoints
+		api.GET("/subtitles/convert", subtitleHandler.Convert)
+		api.POST("/subtitles/convert", subtitleHandler.Convert)
+	}
+
+	// API v2 routes: field berbahasa Inggris dan envelope standar (data, meta, errors)
+	v2 := r.Group("/api/v2", middleware.RenderErrors(handlers.RenderV2Error), middleware.Compress(), middleware.RequestTimeout(requestTimeout))
+	{
+		v2.GET("/home", cached(cache.Home), fieldsV2, v2Handler.GetHome)
+		v2.GET("/ongoing", cached(cache.Lists), formats(modelsv2.Drama{}), fieldsV2, v2Handler.GetOngoing)
+		v2.GET("/movies", cached(cache.Lists), formats(modelsv2.Drama{}), fieldsV2, v2Handler.GetMovies)
+		v2.GET("/schedule", cached(cache.Schedule), fieldsV2, v2Handler.GetSchedule)
+		v2.GET("/schedule/:day", cached(cache.Schedule), formats(modelsv2.ScheduleEntry{}), fieldsV2, v2Handler.GetScheduleByDay)
+		v2.GET("/search", cached(cache.Search), formats(modelsv2.Drama{}), fieldsV2, v2Handler.Search)
+		v2.GET("/search/suggest", cached(cache.Search), formats(modelsv2.Drama{}), fieldsV2, v2Handler.Suggest)
+		v2.GET("/genres", cached(cache.Genres), formats(modelsv2.Genre{}), fieldsV2, v2Handler.GetGenres)
+		v2.GET("/genres/:slug", cached(cache.Lists), formats(modelsv2.Drama{}), fieldsV2, v2Handler.GetDramasByGenre)
+		v2.GET("/dramas/:slug", cached(cache.Detail), fieldsV2, v2Handler.GetDrama)
+		v2.GET("/dramas/:slug/episodes/:n", cached(cache.Episode), fieldsV2, v2Handler.GetEpisode)
+	}
+
+	// Feed RSS/Atom episode baru; format ditentukan ekstensi (.xml atau .atom)
+	feeds := r.Group("/feeds", middleware.RenderErrors(middleware.JSONError), middleware.Compress(), middleware.RequestTimeout(requestTimeout))
+	{
+		feeds.GET("/ongoing.xml", cached(cache.Feeds), feedHandler.GetOngoing)
+		feeds.GET("/ongoing.atom", cached(cache.Feeds), feedHandler.GetOngoing)
+		feeds.GET("/drama/:file", cached(cache.Feeds), feedHandler.GetDrama)
 	}
 
 	// Health check endpoint
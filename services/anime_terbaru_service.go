@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/url"
@@ -9,24 +10,30 @@ import (
 	"time"
 
 	"github.com/gocolly/colly/v2"
+	"github.com/nabilulilalbab/dramaqu/catalog"
 	"github.com/nabilulilalbab/dramaqu/models"
 )
 
+// ongoingPath is the upstream category page of ongoing dramas
+const ongoingPath = "/category/ongoing-drama/"
+
 // AnimeTerbaruService handles anime terbaru data scraping
-type AnimeTerbaruService struct{}
+type AnimeTerbaruService struct {
+	catalog *catalog.Catalog
+	site    Site
+}
 
 // NewAnimeTerbaruService creates a new instance of AnimeTerbaruService
-func NewAnimeTerbaruService() *AnimeTerbaruService {
-	return &AnimeTerbaruService{}
+func NewAnimeTerbaruService(cat *catalog.Catalog, site Site) *AnimeTerbaruService {
+	return &AnimeTerbaruService{catalog: cat, site: site}
 }
 
 // GetAnimeTerbaru scrapes and returns anime terbaru data with the exact same logic as the test
-func (s *AnimeTerbaruService) GetAnimeTerbaru(page int) (*models.OngoingDramaResponse, error) {
+func (s *AnimeTerbaruService) GetAnimeTerbaru(ctx context.Context, page int) (*models.OngoingDramaResponse, error) {
 	// Build target URL based on page number
-	baseURL := "https://dramaqu.ad/category/ongoing-drama/"
-	targetURL := baseURL
+	targetURL := s.ongoingURL()
 	if page > 1 {
-		targetURL = fmt.Sprintf("%spage/%d/", baseURL, page)
+		targetURL = fmt.Sprintf("%spage/%d/", targetURL, page)
 	}
 
 	// Initialize main response struct
@@ -38,10 +45,12 @@ func (s *AnimeTerbaruService) GetAnimeTerbaru(page int) (*models.OngoingDramaRes
 	}
 
 	c := colly.NewCollector(
-		colly.AllowedDomains("dramaqu.ad"),
+		colly.AllowedDomains(s.site.Domains()...),
+		colly.StdlibContext(ctx),
 		colly.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.0.0 Safari/537.36"),
 	)
 	c.SetRequestTimeout(30 * time.Second)
+	s.site.guard(c)
 
 	// Callback for each <article> element containing drama details
 	c.OnHTML("article.movie-preview", func(e *colly.HTMLElement) {
@@ -80,11 +89,14 @@ func (s *AnimeTerbaruService) GetAnimeTerbaru(page int) (*models.OngoingDramaRes
 	})
 
 	// Visit target page
-	err := c.Visit(targetURL)
-	if err != nil {
-		return nil, fmt.Errorf("gagal mengunjungi URL: %v", err)
+	if err := visit(ctx, c, targetURL); err != nil {
+		return nil, err
+	}
+
+	// Simpan hasil scraping ke catalog untuk saran pencarian
+	for _, item := range response.Data {
+		s.catalog.UpsertDrama(catalog.Drama{Slug: item.Slug, Title: item.Judul, URL: item.URL, Cover: item.Cover})
 	}
-	c.Wait()
 
 	// Calculate confidence score based on data completeness
 	response.ConfidenceScore = s.calculateConfidenceScore(response)
@@ -103,6 +115,11 @@ func (s *AnimeTerbaruService) GetAnimeTerbaru(page int) (*models.OngoingDramaRes
 	return response, nil
 }
 
+// ongoingURL returns the first page of ongoing dramas on the upstream site
+func (s *AnimeTerbaruService) ongoingURL() string {
+	return s.site.URL(ongoingPath)
+}
+
 // calculateConfidenceScore calculates confidence score based on data completeness
 func (s *AnimeTerbaruService) calculateConfidenceScore(response *models.OngoingDramaResponse) float64 {
 	if len(response.Data) == 0 {
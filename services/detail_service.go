@@ -1,27 +1,39 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"math/rand"
 	"path"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/gocolly/colly/v2"
+	"github.com/nabilulilalbab/dramaqu/catalog"
 	"github.com/nabilulilalbab/dramaqu/models"
 )
 
-type DetailService struct{}
+type DetailService struct {
+	catalog *catalog.Catalog
+	site    Site
+}
+
+func NewDetailService(cat *catalog.Catalog, site Site) *DetailService {
+	return &DetailService{catalog: cat, site: site}
+}
 
-func NewDetailService() *DetailService {
-	return &DetailService{}
+// LastScraped returns when the drama was last scraped, zero if it is not in the catalog
+func (s *DetailService) LastScraped(slug string) time.Time {
+	drama, _ := s.catalog.Drama(slug)
+	return drama.LastScraped
 }
 
 // GetDetailDrama scrapes and returns detail information with the exact same logic as the test
-func (s *DetailService) GetDetailDrama(animeSlug string) (*models.DetailResponse, error) {
+func (s *DetailService) GetDetailDrama(ctx context.Context, animeSlug string) (*models.DetailResponse, error) {
 	rand.Seed(time.Now().UnixNano())
-	baseURL := "https://dramaqu.ad"
+	baseURL := s.site.BaseURL
 	targetURL := fmt.Sprintf("%s/%s/", baseURL, animeSlug)
 
 	detailResponse := &models.DetailResponse{
@@ -37,11 +49,14 @@ func (s *DetailService) GetDetailDrama(animeSlug string) (*models.DetailResponse
 		Genre:           []string{},
 	}
 
-	c := colly.NewCollector(colly.AllowedDomains("dramaqu.ad"))
+	c := colly.NewCollector(colly.AllowedDomains(s.site.Domains()...), colly.StdlibContext(ctx))
 	c.SetRequestTimeout(30 * time.Second)
+	s.site.guard(c)
 
 	// Info utama (Judul, Cover, Sinopsis)
+	hasContent := false
 	c.OnHTML("div.single-content.movie", func(e *colly.HTMLElement) {
+		hasContent = true
 		detailResponse.Judul = s.cleanTitle(e.ChildText("div.info-right .title span"))
 		detailResponse.Cover = e.ChildAttr("div.info-left .poster img", "src")
 		detailResponse.Sinopsis = strings.TrimSpace(e.ChildText("div.storyline"))
@@ -50,6 +65,19 @@ func (s *DetailService) GetDetailDrama(animeSlug string) (*models.DetailResponse
 		}
 	})
 
+	// Judul alternatif (contoh: romanisasi Korea) untuk saran pencarian
+	var altTitles []string
+	reAltLabel := regexp.MustCompile(`(?i)^\s*(judul\s+alternatif|alternative\s+titles?|also\s+known\s+as|aka|original\s+title|judul\s+asli)\s*:\s*(.+)$`)
+	c.OnHTML("div.single-content.movie li, div.single-content.movie p", func(e *colly.HTMLElement) {
+		if m := reAltLabel.FindStringSubmatch(e.Text); len(m) > 2 {
+			for _, alt := range strings.Split(m[2], ",") {
+				if alt = strings.TrimSpace(alt); alt != "" {
+					altTitles = append(altTitles, alt)
+				}
+			}
+		}
+	})
+
 	// Genre dan Status
 	c.OnHTML("div.categories", func(e *colly.HTMLElement) {
 		e.ForEach("a", func(_ int, el *colly.HTMLElement) {
@@ -75,6 +103,12 @@ func (s *DetailService) GetDetailDrama(animeSlug string) (*models.DetailResponse
 		detailResponse.Rating.Users = fmt.Sprintf("%s users", users)
 	})
 
+	// Tanggal post dari metadata halaman untuk tanggal rilis episode
+	var dates postDates
+	c.OnHTML("html", func(e *colly.HTMLElement) {
+		dates = extractPostDates(e.DOM)
+	})
+
 	// Daftar Episode
 	c.OnHTML("div#action-parts", func(e *colly.HTMLElement) {
 		e.ForEach("div.keremiya_part > *", func(_ int, el *colly.HTMLElement) {
@@ -119,14 +153,47 @@ func (s *DetailService) GetDetailDrama(animeSlug string) (*models.DetailResponse
 		log.Printf("Error saat request ke %s: %v", r.Request.URL, err)
 	})
 
-	err := c.Visit(targetURL)
-	if err != nil {
-		return nil, fmt.Errorf("gagal mengunjungi URL: %v", err)
+	notFound := watchNotFound(c)
+	if err := visit(ctx, c, targetURL); err != nil {
+		return nil, err
+	}
+	if notFound() {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, targetURL)
+	}
+	// Halaman tanpa konten drama (artikel, kategori, dll.) berarti slug tidak dikenal;
+	// konten tanpa judul berarti struktur halaman sumber berubah
+	if !hasContent {
+		return nil, fmt.Errorf("%w: %s bukan halaman drama", ErrNotFound, targetURL)
+	}
+	if detailResponse.Judul == "" {
+		return nil, fmt.Errorf("%w: judul drama tidak ditemukan di %s", ErrUpstreamChanged, targetURL)
 	}
-	c.Wait()
 
 	log.Println("Scraping detail selesai.")
 
+	// Isi tanggal rilis setiap episode setelah seluruh daftar episode diketahui
+	latestNum := 0
+	for _, episode := range detailResponse.EpisodeList {
+		if n := parseEpisodeNumber(episode.Episode); n > latestNum {
+			latestNum = n
+		}
+	}
+	for i, episode := range detailResponse.EpisodeList {
+		detailResponse.EpisodeList[i].ReleaseDate = episodeReleaseDate(s.catalog, episode.URL, parseEpisodeNumber(episode.Episode), latestNum, dates)
+	}
+
+	// Simpan hasil scraping ke catalog untuk saran pencarian
+	s.catalog.UpsertDrama(catalog.Drama{
+		Slug:     animeSlug,
+		Title:    detailResponse.Judul,
+		URL:      targetURL,
+		Cover:    detailResponse.Cover,
+		Variants: altTitles,
+	})
+	for _, rec := range detailResponse.Recommendations {
+		s.catalog.UpsertDrama(catalog.Drama{Slug: rec.AnimeSlug, Title: rec.Title, URL: rec.URL, Cover: rec.CoverURL})
+	}
+
 	// Mengisi data dummy untuk object "details"
 	detailResponse.Details.Japanese = detailResponse.Judul
 	detailResponse.Details.English = detailResponse.Judul
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/nabilulilalbab/dramaqu/models"
+)
+
+// downloadSectionSelector matches the containers used for download links on episode pages
+const downloadSectionSelector = "div#download, div.download, div.downloads, div.download-links, div.download-area, div.dl-box, div.soraddl"
+
+var (
+	reDownloadQuality = regexp.MustCompile(`(?i)\b(2160p|1440p|1080p|720p|540p|480p|360p|240p|4k)\b`)
+	reFormatX265      = regexp.MustCompile(`(?i)\b(x265|hevc|h\.?265)\b`)
+	reFormatMKV       = regexp.MustCompile(`(?i)\bmkv\b`)
+	reFormatMP4       = regexp.MustCompile(`(?i)\bmp4\b`)
+)
+
+// providerHosts maps provider domains to their display name. Host dicocokkan per label
+// domain (mega.nz cocok dengan www.mega.nz, tetapi megaup.net tidak).
+var providerHosts = []struct {
+	domain string
+	name   string
+}{
+	{"drive.google.com", "Google Drive"},
+	{"docs.google.com", "Google Drive"},
+	{"mega.nz", "Mega"},
+	{"mega.co.nz", "Mega"},
+	{"mediafire.com", "MediaFire"},
+	{"pixeldrain.com", "Pixeldrain"},
+	{"terabox.com", "TeraBox"},
+	{"teraboxapp.com", "TeraBox"},
+	{"krakenfiles.com", "KrakenFiles"},
+	{"gofile.io", "GoFile"},
+	{"acefile.co", "Acefile"},
+	{"racaty.net", "Racaty"},
+	{"racaty.io", "Racaty"},
+	{"uptobox.com", "Uptobox"},
+	{"1fichier.com", "1fichier"},
+	{"send.cm", "Send.cm"},
+	{"zippyshare.com", "Zippyshare"},
+	{"filedon.co", "Filedon"},
+	{"buzzheavier.com", "BuzzHeavier"},
+}
+
+// providerNames maps whole words in the link text to their display name,
+// dipakai jika host link bukan provider yang dikenal
+var providerNames = []struct {
+	keyword string
+	name    string
+}{
+	{"google drive", "Google Drive"},
+	{"googledrive", "Google Drive"},
+	{"gdrive", "Google Drive"},
+	{"mediafire", "MediaFire"},
+	{"pixeldrain", "Pixeldrain"},
+	{"terabox", "TeraBox"},
+	{"krakenfiles", "KrakenFiles"},
+	{"kraken", "KrakenFiles"},
+	{"gofile", "GoFile"},
+	{"acefile", "Acefile"},
+	{"racaty", "Racaty"},
+	{"uptobox", "Uptobox"},
+	{"1fichier", "1fichier"},
+	{"send.cm", "Send.cm"},
+	{"sendcm", "Send.cm"},
+	{"zippyshare", "Zippyshare"},
+	{"filedon", "Filedon"},
+	{"buzzheavier", "BuzzHeavier"},
+}
+
+// parseDownloadLinks parses the download section of an episode page into
+// format → quality → providers. Halaman tanpa bagian download menghasilkan map kosong.
+func (s *EpisodeDetailService) parseDownloadLinks(doc *goquery.Selection) models.DownloadLinks {
+	links := models.DownloadLinks{
+		MKV:  make(map[string][]models.DownloadProvider),
+		MP4:  make(map[string][]models.DownloadProvider),
+		X265: make(map[string][]models.DownloadProvider),
+	}
+
+	doc.Find(downloadSectionSelector).Each(func(_ int, section *goquery.Selection) {
+		currentFormat := ""
+		section.Find("*").Each(func(_ int, el *goquery.Selection) {
+			name := goquery.NodeName(el)
+
+			// Judul format (contoh: <h3>MKV</h3>) mengubah format untuk baris berikutnya
+			if s.isDownloadHeading(el, name) {
+				if format := s.detectFormat(el.Text()); format != "" {
+					currentFormat = format
+				}
+				return
+			}
+
+			if name != "li" && name != "tr" && name != "p" {
+				return
+			}
+			// <p> di dalam baris <li>/<tr> sudah diproses oleh baris induknya
+			if name == "p" && el.ParentsFiltered("li, tr").Length() > 0 {
+				return
+			}
+			// Baris yang berisi sub-daftar hanya menentukan format, link diproses di sub-barisnya
+			if el.Find("li, tr").Find("a[href]").Length() > 0 {
+				own := el.Clone()
+				own.Find("ul, ol, table").Remove()
+				if format := s.detectFormat(own.Text()); format != "" {
+					currentFormat = format
+				}
+				return
+			}
+			anchors := el.Find("a[href]")
+			if anchors.Length() == 0 {
+				return
+			}
+
+			label := el.Clone()
+			label.Find("a").Remove()
+			labelText := strings.TrimSpace(label.Text())
+
+			format := s.detectFormat(labelText)
+			if format == "" {
+				format = currentFormat
+			}
+			quality := strings.ToLower(reDownloadQuality.FindString(labelText))
+			if quality == "4k" {
+				quality = "2160p"
+			}
+
+			anchors.Each(func(_ int, a *goquery.Selection) {
+				href := strings.TrimSpace(a.AttrOr("href", ""))
+				if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+					return
+				}
+				rowFormat := format
+				if rowFormat == "" {
+					rowFormat = s.detectFormat(href)
+				}
+				if rowFormat == "" {
+					rowFormat = "MP4"
+				}
+				rowQuality := quality
+				if rowQuality == "" {
+					rowQuality = strings.ToLower(reDownloadQuality.FindString(a.Text() + " " + href))
+				}
+				if rowQuality == "" {
+					rowQuality = "Unknown"
+				}
+
+				provider := models.DownloadProvider{
+					Provider: s.normalizeProviderName(a.Text(), href),
+					URL:      href,
+				}
+				target := links.MP4
+				switch rowFormat {
+				case "MKV":
+					target = links.MKV
+				case "X265":
+					target = links.X265
+				}
+				target[rowQuality] = append(target[rowQuality], provider)
+			})
+		})
+	})
+
+	return links
+}
+
+// isDownloadHeading reports whether el is a short heading-like element without links
+func (s *EpisodeDetailService) isDownloadHeading(el *goquery.Selection, name string) bool {
+	switch name {
+	case "h1", "h2", "h3", "h4", "h5", "h6", "th", "strong", "b":
+	default:
+		if !el.HasClass("title") && !el.HasClass("download-title") {
+			return false
+		}
+	}
+	if el.Find("a").Length() > 0 || el.ParentsFiltered("a").Length() > 0 {
+		return false
+	}
+	// <strong> di dalam baris link adalah label kualitas, bukan judul format
+	if (name == "strong" || name == "b") && el.ParentsFiltered("li, tr, p").Find("a[href]").Length() > 0 {
+		return false
+	}
+	return len(strings.TrimSpace(el.Text())) <= 80
+}
+
+// detectFormat returns MKV, MP4 or X265 if text names a download format
+func (s *EpisodeDetailService) detectFormat(text string) string {
+	switch {
+	case reFormatX265.MatchString(text):
+		return "X265"
+	case reFormatMKV.MatchString(text):
+		return "MKV"
+	case reFormatMP4.MatchString(text):
+		return "MP4"
+	}
+	return ""
+}
+
+// normalizeProviderName maps the link host, or whole words of the link text, to a consistent provider name
+func (s *EpisodeDetailService) normalizeProviderName(text, href string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	host := ""
+	if parsedURL, err := url.Parse(href); err == nil {
+		host = strings.TrimPrefix(strings.ToLower(parsedURL.Hostname()), "www.")
+	}
+
+	// Host lebih bisa dipercaya daripada teks link
+	for _, p := range providerHosts {
+		if host == p.domain || strings.HasSuffix(host, "."+p.domain) {
+			return p.name
+		}
+	}
+	words := " " + strings.Join(strings.FieldsFunc(strings.ToLower(text), isProviderSeparator), " ") + " "
+	for _, p := range providerNames {
+		if strings.Contains(words, " "+p.keyword+" ") {
+			return p.name
+		}
+	}
+
+	if text != "" && !strings.EqualFold(text, "download") {
+		return text
+	}
+	if host != "" {
+		return host
+	}
+	return "Unknown"
+}
+
+// isProviderSeparator splits link text into words; titik dipertahankan untuk nama seperti send.cm
+func isProviderSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.'
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/nabilulilalbab/dramaqu/models"
+)
+
+func loadFixture(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("failed to read fixture %s: %v", name, err)
+	}
+	return string(data)
+}
+
+func TestParseDownloadLinksList(t *testing.T) {
+	s := &EpisodeDetailService{}
+	links := s.parseDownloadLinks(mustParseDoc(t, loadFixture(t, "download_links_list.html")))
+
+	wantMKV := map[string][]models.DownloadProvider{
+		"360p": {
+			{Provider: "Google Drive", URL: "https://drive.google.com/file/d/aaa/view"},
+			{Provider: "Mega", URL: "https://mega.nz/file/bbb"},
+		},
+		"720p": {
+			{Provider: "Google Drive", URL: "https://drive.google.com/file/d/ccc/view"},
+			{Provider: "MediaFire", URL: "https://www.mediafire.com/file/ddd"},
+		},
+	}
+	wantMP4 := map[string][]models.DownloadProvider{
+		"540p": {{Provider: "Pixeldrain", URL: "https://pixeldrain.com/u/eee"}},
+		"720p": {{Provider: "TeraBox", URL: "https://terabox.com/s/fff"}},
+	}
+	wantX265 := map[string][]models.DownloadProvider{
+		"720p": {{Provider: "Acefile", URL: "https://acefile.co/f/ggg"}},
+	}
+
+	if !reflect.DeepEqual(links.MKV, wantMKV) {
+		t.Errorf("MKV = %+v, want %+v", links.MKV, wantMKV)
+	}
+	if !reflect.DeepEqual(links.MP4, wantMP4) {
+		t.Errorf("MP4 = %+v, want %+v", links.MP4, wantMP4)
+	}
+	if !reflect.DeepEqual(links.X265, wantX265) {
+		t.Errorf("X265 = %+v, want %+v", links.X265, wantX265)
+	}
+}
+
+func TestParseDownloadLinksTable(t *testing.T) {
+	s := &EpisodeDetailService{}
+	links := s.parseDownloadLinks(mustParseDoc(t, loadFixture(t, "download_links_table.html")))
+
+	wantMKV := map[string][]models.DownloadProvider{
+		"480p": {{Provider: "KrakenFiles", URL: "https://krakenfiles.com/view/hhh/file.html"}},
+		"1080p": {
+			{Provider: "GoFile", URL: "https://gofile.io/d/iii"},
+			{Provider: "Send.cm", URL: "https://send.cm/jjj"},
+		},
+	}
+	wantX265 := map[string][]models.DownloadProvider{
+		"720p": {{Provider: "1fichier", URL: "https://1fichier.com/?kkk"}},
+	}
+
+	if !reflect.DeepEqual(links.MKV, wantMKV) {
+		t.Errorf("MKV = %+v, want %+v", links.MKV, wantMKV)
+	}
+	if len(links.MP4) != 0 {
+		t.Errorf("MP4 = %+v, want empty", links.MP4)
+	}
+	if !reflect.DeepEqual(links.X265, wantX265) {
+		t.Errorf("X265 = %+v, want %+v", links.X265, wantX265)
+	}
+}
+
+func TestParseDownloadLinksWithoutDownloadSection(t *testing.T) {
+	s := &EpisodeDetailService{}
+	links := s.parseDownloadLinks(mustParseDoc(t, loadFixture(t, "download_links_none.html")))
+
+	if links.MKV == nil || links.MP4 == nil || links.X265 == nil {
+		t.Fatal("download maps must be initialized so they serialize as {} instead of null")
+	}
+	if len(links.MKV)+len(links.MP4)+len(links.X265) != 0 {
+		t.Errorf("expected no download links, got %+v", links)
+	}
+}
+
+func TestNormalizeProviderName(t *testing.T) {
+	s := &EpisodeDetailService{}
+	tests := []struct {
+		text, href, want string
+	}{
+		{"MEGA", "https://mega.nz/file/a", "Mega"},
+		{"Download", "https://mega.co.nz/#!b", "Mega"},
+		{"Link", "https://www.mega.nz/file/c", "Mega"},
+		// Host atau label yang hanya mengandung "mega" bukan Mega
+		{"Megaup", "https://megaup.net/d", "Megaup"},
+		{"Download", "https://omegafiles.com/e", "omegafiles.com"},
+		{"Mega Pack 720p", "https://pixeldrain.com/u/f", "Pixeldrain"},
+		{"MEGA", "https://cdn.example.com/g", "MEGA"},
+		{"Google Drive", "https://example.com/h", "Google Drive"},
+		{"", "", "Unknown"},
+	}
+	for _, tt := range tests {
+		if got := s.normalizeProviderName(tt.text, tt.href); got != tt.want {
+			t.Errorf("normalizeProviderName(%q, %q) = %q, want %q", tt.text, tt.href, got, tt.want)
+		}
+	}
+}
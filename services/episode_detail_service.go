@@ -1,35 +1,43 @@
 package services
 
 import (
-	"encoding/base64"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"net/url"
 	"path"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/gocolly/colly/v2"
+	"github.com/nabilulilalbab/dramaqu/catalog"
 	"github.com/nabilulilalbab/dramaqu/models"
 )
 
-type EpisodeDetailService struct{}
+type EpisodeDetailService struct {
+	catalog *catalog.Catalog
+	site    Site
+}
 
-func NewEpisodeDetailService() *EpisodeDetailService {
-	return &EpisodeDetailService{}
+func NewEpisodeDetailService(cat *catalog.Catalog, site Site) *EpisodeDetailService {
+	return &EpisodeDetailService{catalog: cat, site: site}
 }
 
 // GetEpisodeDetail scrapes and returns episode detail with the exact same logic as the test
-func (s *EpisodeDetailService) GetEpisodeDetail(episodeURL string) (*models.EpisodeDetailResponse, error) {
+func (s *EpisodeDetailService) GetEpisodeDetail(ctx context.Context, episodeURL string) (*models.EpisodeDetailResponse, error) {
 	episodeResponse := &models.EpisodeDetailResponse{
 		ConfidenceScore: 1.0,
 		Message:         "Success",
 		Source:          "dramaqu.ad",
-		ReleaseInfo:     fmt.Sprintf("Released on %s %d", time.Now().Month().String(), time.Now().Year()),
 		DownloadLinks: models.DownloadLinks{
 			MKV:  make(map[string][]models.DownloadProvider),
 			MP4:  make(map[string][]models.DownloadProvider),
@@ -37,14 +45,20 @@ func (s *EpisodeDetailService) GetEpisodeDetail(episodeURL string) (*models.Epis
 		},
 		AnimeInfo:        models.AnimeInfo{},
 		StreamingServers: []models.StreamingServer{}, // Initialize slice to avoid null
+		Subtitles:        []models.StreamSubtitle{},
 		OtherEpisodes:    []models.OtherEpisode{},
 	}
 
 	c := colly.NewCollector(
-		colly.AllowedDomains("dramaqu.ad"),
+		colly.AllowedDomains(s.site.Domains()...),
+		colly.StdlibContext(ctx),
 		colly.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.0.0 Safari/537.36"),
+		colly.Async(true),
 	)
 	c.SetRequestTimeout(30 * time.Second)
+	s.site.guard(c)
+	// Batasi jumlah permintaan AJAX player yang berjalan bersamaan
+	c.Limit(&colly.LimitRule{DomainGlob: "*", Parallelism: 4})
 
 	c.OnHTML("body", func(e *colly.HTMLElement) {
 		log.Println("Mem-parsing HTML dari halaman utama...")
@@ -61,6 +75,9 @@ func (s *EpisodeDetailService) GetEpisodeDetail(episodeURL string) (*models.Epis
 			episodeResponse.AnimeInfo.Genres = append(episodeResponse.AnimeInfo.Genres, s.Text())
 		})
 
+		// Link download dikelompokkan berdasarkan format → kualitas → provider
+		episodeResponse.DownloadLinks = s.parseDownloadLinks(doc)
+
 		baseDramaURL, currentEpNum := "", "1"
 		reEp := regexp.MustCompile(`(https?://[^/]+/[^/]+)/(\d+)/?$`)
 		reBase := regexp.MustCompile(`(https?://[^/]+/[^/]+)/?$`)
@@ -85,11 +102,25 @@ func (s *EpisodeDetailService) GetEpisodeDetail(episodeURL string) (*models.Epis
 			}
 		}
 
-		doc.Find("div#action-parts a.post-page-numbers").Each(func(i int, s *goquery.Selection) {
-			epNum := s.Find("span").Text()
-			epURL := s.AttrOr("href", "")
+		// Tanggal rilis per episode dari metadata halaman (lihat episodeReleaseDate)
+		dates := extractPostDates(doc)
+		episodeLinks := doc.Find("div#action-parts a.post-page-numbers")
+		latestNum := num
+		episodeLinks.Each(func(_ int, sel *goquery.Selection) {
+			if n := parseEpisodeNumber(sel.Find("span").Text()); n > latestNum {
+				latestNum = n
+			}
+		})
+		episodeResponse.ReleaseInfo = episodeReleaseDate(s.catalog, episodeURL, num, latestNum, dates)
+
+		episodeLinks.Each(func(_ int, sel *goquery.Selection) {
+			epNum := sel.Find("span").Text()
+			epURL := sel.AttrOr("href", "")
 			episodeResponse.OtherEpisodes = append(episodeResponse.OtherEpisodes, models.OtherEpisode{
-				Title: "Episode " + epNum, URL: epURL, ThumbnailURL: thumbnail, ReleaseDate: "Unknown",
+				Title:        "Episode " + epNum,
+				URL:          epURL,
+				ThumbnailURL: thumbnail,
+				ReleaseDate:  episodeReleaseDate(s.catalog, epURL, parseEpisodeNumber(epNum), latestNum, dates),
 			})
 		})
 
@@ -104,54 +135,58 @@ func (s *EpisodeDetailService) GetEpisodeDetail(episodeURL string) (*models.Epis
 
 		// Mendapatkan parameter AJAX
 		log.Println("Mencari parameter AJAX...")
-		playerID, exists := doc.Find("div.apicodes-container").Attr("id")
-		if !exists {
+		players := s.findPlayerSources(doc)
+		if len(players) == 0 {
 			log.Println("PERINGATAN: Tidak dapat menemukan Player ID.")
+			episodeResponse.Warnings = append(episodeResponse.Warnings, models.ExtractionWarning{
+				Code:    WarningPlayerIDNotFound,
+				Message: "tidak ada container atau tab player (div.apicodes-container, [data-player-id], [data-id]) di halaman",
+			})
 			return
 		}
 
-		var nonce string
-		dataUri, exists := doc.Find("script#dramagu-player-js-extra").Attr("src")
-		if !exists {
-			log.Println("PERINGATAN: Tidak dapat menemukan script tag '#dramagu-player-js-extra'.")
-			return
-		}
-
-		parts := strings.SplitN(dataUri, ",", 2)
-		if len(parts) != 2 {
-			log.Println("PERINGATAN: Format data URI pada script tidak valid.")
-			return
-		}
-
-		decodedScript, err := base64.StdEncoding.DecodeString(parts[1])
-		if err != nil {
-			log.Printf("PERINGATAN: Gagal men-decode base64: %v", err)
-			return
-		}
-
-		reNonce := regexp.MustCompile(`"nonce":"(\w+)"`)
-		nonceMatches := reNonce.FindStringSubmatch(string(decodedScript))
-		if len(nonceMatches) > 1 {
-			nonce = nonceMatches[1]
-		} else {
-			log.Println("PERINGATAN: Gagal mengekstrak nonce dari script yang di-decode.")
+		// Nonce dicari dengan beberapa strategi secara berurutan
+		nonce, strategy, warnings := s.extractNonce(doc)
+		if nonce == "" {
+			for _, w := range warnings {
+				log.Printf("PERINGATAN: Strategi nonce %s gagal: %s", w.Strategy, w.Message)
+			}
+			episodeResponse.Warnings = append(episodeResponse.Warnings, warnings...)
 			return
 		}
+		log.Printf("Nonce ditemukan dengan strategi %s", strategy)
+		episodeResponse.NonceStrategy = strategy
 
-		// Kirim permintaan AJAX
-		log.Printf("Parameter ditemukan: PlayerID=%s, Nonce=%s", playerID, nonce)
+		// Kirim permintaan AJAX untuk setiap player secara paralel (collector berjalan async)
 		ajaxURL := e.Request.AbsoluteURL("/wp-admin/admin-ajax.php")
-		formData := map[string]string{
-			"action":    "get_player_url",
-			"player_id": playerID,
-			"nonce":     nonce,
-		}
-		log.Printf("Mengirim permintaan POST ke: %s", ajaxURL)
-		if err := c.Post(ajaxURL, formData); err != nil {
-			log.Printf("Gagal mengirimkan permintaan AJAX: %v", err)
+		for _, player := range players {
+			// Client sudah pergi atau deadline lewat, tidak perlu mengirim sisa permintaan
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Parameter ditemukan: PlayerID=%s, Nonce=%s", player.ID, nonce)
+			formData := url.Values{
+				"action":    {"get_player_url"},
+				"player_id": {player.ID},
+				"nonce":     {nonce},
+			}
+			playerCtx := colly.NewContext()
+			playerCtx.Put("player_id", player.ID)
+			playerCtx.Put("player_label", player.Label)
+			playerCtx.Put("player_order", strconv.Itoa(player.Order))
+			hdr := http.Header{"Content-Type": {"application/x-www-form-urlencoded"}}
+
+			log.Printf("Mengirim permintaan POST ke: %s", ajaxURL)
+			if err := c.Request("POST", ajaxURL, strings.NewReader(formData.Encode()), playerCtx, hdr); err != nil {
+				log.Printf("Gagal mengirimkan permintaan AJAX: %v", err)
+			}
 		}
 	})
 
+	// Urutan server mengikuti urutan tab player di halaman
+	serverOrder := make(map[string]int)
+	var serversMu sync.Mutex
+
 	c.OnResponse(func(r *colly.Response) {
 		if strings.Contains(r.Request.URL.String(), "admin-ajax.php") {
 			log.Println("Menerima respons dari AJAX call.")
@@ -172,15 +207,25 @@ func (s *EpisodeDetailService) GetEpisodeDetail(episodeURL string) (*models.Epis
 					serverName = strings.ReplaceAll(parsedURL.Hostname(), "www.", "")
 				}
 
-				// Tambahkan hanya satu server streaming yang valid
-				episodeResponse.StreamingServers = append(episodeResponse.StreamingServers, models.StreamingServer{
+				playerID := r.Ctx.Get("player_id")
+				label := r.Ctx.Get("player_label")
+				if label == "" {
+					label = serverName
+				}
+				order, _ := strconv.Atoi(r.Ctx.Get("player_order"))
+
+				server := models.StreamingServer{
+					ServerID:     s.serverID(episodeURL, playerID),
 					ServerName:   serverName,
+					Label:        label,
+					Quality:      s.qualityHint(label, iframeSrc),
 					StreamingURL: iframeSrc,
-				})
+				}
 
-				// Tambahkan juga satu link download sebagai contoh (opsional)
-				provider := models.DownloadProvider{Provider: serverName, URL: iframeSrc}
-				episodeResponse.DownloadLinks.MKV["720p"] = []models.DownloadProvider{provider}
+				serversMu.Lock()
+				defer serversMu.Unlock()
+				serverOrder[server.ServerID] = order
+				episodeResponse.StreamingServers = append(episodeResponse.StreamingServers, server)
 
 			} else {
 				log.Println("Respons AJAX tidak berhasil atau URL iframe kosong.")
@@ -196,12 +241,33 @@ func (s *EpisodeDetailService) GetEpisodeDetail(episodeURL string) (*models.Epis
 		log.Printf("Gagal saat request ke %s: %v", r.Request.URL, err)
 	})
 
-	err := c.Visit(episodeURL)
-	if err != nil {
-		return nil, fmt.Errorf("gagal mengunjungi URL: %v", err)
+	notFound := watchNotFound(c)
+	if err := visit(ctx, c, episodeURL); err != nil {
+		return nil, err
+	}
+	if notFound() {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, episodeURL)
 	}
 
-	c.Wait()
+	sort.SliceStable(episodeResponse.StreamingServers, func(i, j int) bool {
+		return serverOrder[episodeResponse.StreamingServers[i].ServerID] < serverOrder[episodeResponse.StreamingServers[j].ServerID]
+	})
+
+	// Daftarkan server ke catalog untuk health check, lalu urutkan berdasarkan kesehatannya
+	for _, server := range episodeResponse.StreamingServers {
+		s.catalog.TrackServer(server.StreamingURL, server.ServerName)
+	}
+	ApplyServerHealth(s.catalog, episodeResponse)
+
+	// Simpan info drama ke catalog untuk saran pencarian
+	if episodeResponse.AnimeInfo.Title != "" && episodeResponse.Navigation.AllEpisodesURL != "" {
+		s.catalog.UpsertDrama(catalog.Drama{
+			Slug:  s.generateSlug(episodeResponse.Navigation.AllEpisodesURL),
+			Title: episodeResponse.AnimeInfo.Title,
+			URL:   episodeResponse.Navigation.AllEpisodesURL,
+			Cover: episodeResponse.AnimeInfo.ThumbnailURL,
+		})
+	}
 
 	// Calculate confidence score based on data completeness
 	episodeResponse.ConfidenceScore = s.calculateConfidenceScore(episodeResponse)
@@ -220,6 +286,73 @@ func (s *EpisodeDetailService) GetEpisodeDetail(episodeURL string) (*models.Epis
 	return episodeResponse, nil
 }
 
+// playerSource represents a player tab/mirror found on the episode page
+type playerSource struct {
+	ID    string
+	Label string
+	Order int
+}
+
+// reQualityHint matches quality labels such as "720p", "HD" or "4K"
+var reQualityHint = regexp.MustCompile(`(?i)\b(2160p|1440p|1080p|720p|480p|360p|240p|4k|fhd|hd|sd)\b`)
+
+// findPlayerSources returns every player container and tab on the page in page order
+func (s *EpisodeDetailService) findPlayerSources(doc *goquery.Selection) []playerSource {
+	var players []playerSource
+	seen := make(map[string]bool)
+
+	add := func(id, label string) {
+		id = strings.TrimPrefix(strings.TrimSpace(id), "#")
+		if id == "" || seen[id] {
+			return
+		}
+		seen[id] = true
+		players = append(players, playerSource{ID: id, Label: strings.TrimSpace(label), Order: len(players)})
+	}
+
+	// Label diambil dari tab yang mereferensikan container player
+	tabLabel := func(id string) string {
+		selector := fmt.Sprintf("[data-target='#%[1]s'], a[href='#%[1]s'], [data-player-id='%[1]s'], [data-id='%[1]s']", id)
+		return doc.Find(selector).Not("div.apicodes-container").First().Text()
+	}
+
+	doc.Find("div.apicodes-container[id]").Each(func(_ int, sel *goquery.Selection) {
+		id := sel.AttrOr("id", "")
+		label := sel.AttrOr("data-label", sel.AttrOr("data-title", ""))
+		if label == "" {
+			label = tabLabel(id)
+		}
+		add(id, label)
+	})
+
+	// Tab player yang container-nya belum dimuat di halaman
+	doc.Find("[data-player-id], .apicodes-tabs [data-id], .player-tabs [data-id]").Each(func(_ int, sel *goquery.Selection) {
+		id := sel.AttrOr("data-player-id", sel.AttrOr("data-id", ""))
+		add(id, sel.Text())
+	})
+
+	return players
+}
+
+// serverID returns a stable identifier for a player on an episode page
+func (s *EpisodeDetailService) serverID(episodeURL, playerID string) string {
+	sum := sha1.Sum([]byte(strings.TrimSuffix(episodeURL, "/") + "#" + playerID))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// qualityHint extracts a quality label from the tab label or the player URL
+func (s *EpisodeDetailService) qualityHint(label, playerURL string) string {
+	for _, text := range []string{label, playerURL} {
+		if m := reQualityHint.FindString(text); m != "" {
+			if strings.HasSuffix(strings.ToLower(m), "p") {
+				return strings.ToLower(m)
+			}
+			return strings.ToUpper(m)
+		}
+	}
+	return ""
+}
+
 // calculateConfidenceScore calculates confidence score for episode detail response
 func (s *EpisodeDetailService) calculateConfidenceScore(response *models.EpisodeDetailResponse) float64 {
 	// Required fields: Title, ThumbnailURL, StreamingServers (at least 1), Navigation.AllEpisodesURL
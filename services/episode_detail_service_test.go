@@ -0,0 +1,80 @@
+package services
+
+import "testing"
+
+func TestFindPlayerSources(t *testing.T) {
+	doc := mustParseDoc(t, `<html><body>
+		<ul class="apicodes-tabs">
+			<li data-target="#player-a">Server 1 HD</li>
+			<li data-target="#player-b">Mirror 720p</li>
+			<li data-id="player-c">Backup</li>
+		</ul>
+		<div class="apicodes-container" id="player-a"></div>
+		<div class="apicodes-container" id="player-b"></div>
+	</body></html>`)
+
+	s := &EpisodeDetailService{}
+	players := s.findPlayerSources(doc)
+
+	want := []playerSource{
+		{ID: "player-a", Label: "Server 1 HD", Order: 0},
+		{ID: "player-b", Label: "Mirror 720p", Order: 1},
+		{ID: "player-c", Label: "Backup", Order: 2},
+	}
+	if len(players) != len(want) {
+		t.Fatalf("found %d players, want %d: %+v", len(players), len(want), players)
+	}
+	for i := range want {
+		if players[i] != want[i] {
+			t.Errorf("player %d = %+v, want %+v", i, players[i], want[i])
+		}
+	}
+}
+
+func TestQualityHintAndServerID(t *testing.T) {
+	s := &EpisodeDetailService{}
+
+	if got := s.qualityHint("Mirror 720P", ""); got != "720p" {
+		t.Errorf("qualityHint label = %q, want 720p", got)
+	}
+	if got := s.qualityHint("Server 2", "https://embed.example/v/abc?q=1080p"); got != "1080p" {
+		t.Errorf("qualityHint url = %q, want 1080p", got)
+	}
+	if got := s.qualityHint("Server 2", "https://embed.example/v/abc"); got != "" {
+		t.Errorf("qualityHint without hint = %q, want empty", got)
+	}
+
+	a := s.serverID("https://dramaqu.ad/drama/2/", "player-a")
+	if a != s.serverID("https://dramaqu.ad/drama/2", "player-a") {
+		t.Error("serverID should ignore trailing slash")
+	}
+	if a == s.serverID("https://dramaqu.ad/drama/2/", "player-b") {
+		t.Error("serverID should differ per player")
+	}
+}
+
+func TestEpisodeURLAndRef(t *testing.T) {
+	s := NewEpisodeDetailService(nil, NewSite("https://mirror.example/", []string{"mirror.example"}))
+
+	if got := s.EpisodeURL("love-in-the-air", 1); got != "https://mirror.example/love-in-the-air/" {
+		t.Errorf("EpisodeURL episode 1 = %q", got)
+	}
+	if got := s.EpisodeURL("love-in-the-air", 12); got != "https://mirror.example/love-in-the-air/12/" {
+		t.Errorf("EpisodeURL episode 12 = %q", got)
+	}
+	if !s.AllowsHost("mirror.example") || s.AllowsHost("dramaqu.ad") {
+		t.Errorf("AllowsHost must follow the configured allowlist")
+	}
+
+	valid := map[string]int{"3": 3, "episode-3": 3, "love-in-the-air-episode-3": 3, " 10 ": 10}
+	for ref, want := range valid {
+		if n, err := ParseEpisodeRef("love-in-the-air", ref); err != nil || n != want {
+			t.Errorf("ParseEpisodeRef(%q) = %d, %v; want %d", ref, n, err, want)
+		}
+	}
+	for _, ref := range []string{"", "0", "abc", "other-drama-episode-3", "episode-", "-1"} {
+		if _, err := ParseEpisodeRef("love-in-the-air", ref); err == nil {
+			t.Errorf("ParseEpisodeRef(%q) must fail", ref)
+		}
+	}
+}
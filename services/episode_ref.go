@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nabilulilalbab/dramaqu/models"
+)
+
+// ErrInvalidEpisodeRef is returned when an episode number or EpisodeSlug cannot be parsed
+var ErrInvalidEpisodeRef = errors.New("referensi episode tidak valid")
+
+// reEpisodeRef matches "3", "episode-3" and the EpisodeSlug format "{slug}-episode-3"
+var reEpisodeRef = regexp.MustCompile(`^(?:(?:(.+)-)?episode-)?(\d+)$`)
+
+// ParseEpisodeRef parses an episode reference for the given drama slug. Selain angka,
+// format EpisodeSlug dari response detail juga diterima selama slug-nya sama.
+func ParseEpisodeRef(slug, ref string) (int, error) {
+	matches := reEpisodeRef.FindStringSubmatch(strings.ToLower(strings.TrimSpace(ref)))
+	if matches == nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidEpisodeRef, ref)
+	}
+	if matches[1] != "" && matches[1] != slug {
+		return 0, fmt.Errorf("%w: episode slug %q bukan milik drama %q", ErrInvalidEpisodeRef, ref, slug)
+	}
+
+	n, err := strconv.Atoi(matches[2])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%w: nomor episode harus lebih dari 0", ErrInvalidEpisodeRef)
+	}
+	return n, nil
+}
+
+// EpisodeURL builds the upstream episode URL from the configured base URL.
+// Episode 1 ada di halaman drama itu sendiri, episode berikutnya di /{n}/.
+func (s *EpisodeDetailService) EpisodeURL(slug string, n int) string {
+	return episodeURL(s.site.URL("/"+url.PathEscape(slug)+"/"), n)
+}
+
+// episodeURL returns the URL of episode n of the drama page at dramaURL
+func episodeURL(dramaURL string, n int) string {
+	base := strings.TrimSuffix(dramaURL, "/") + "/"
+	if n <= 1 {
+		return base
+	}
+	return base + strconv.Itoa(n) + "/"
+}
+
+// GetEpisodeBySlug scrapes an episode addressed by drama slug and episode number
+func (s *EpisodeDetailService) GetEpisodeBySlug(ctx context.Context, slug string, n int) (*models.EpisodeDetailResponse, error) {
+	return s.GetEpisodeDetail(ctx, s.EpisodeURL(slug, n))
+}
+
+// LastScraped returns when the drama was last scraped, zero if it is not in the catalog
+func (s *EpisodeDetailService) LastScraped(slug string) time.Time {
+	drama, _ := s.catalog.Drama(slug)
+	return drama.LastScraped
+}
+
+// AllowsHost reports whether host is one of the configured upstream hosts
+func (s *EpisodeDetailService) AllowsHost(host string) bool {
+	for _, allowed := range s.site.Domains() {
+		if strings.EqualFold(allowed, host) {
+			return true
+		}
+	}
+	return false
+}
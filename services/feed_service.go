@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nabilulilalbab/dramaqu/catalog"
+	"github.com/nabilulilalbab/dramaqu/feed"
+	"github.com/nabilulilalbab/dramaqu/models"
+)
+
+// Feed metadata shared by all feeds
+const (
+	feedLanguage = "id"
+	feedAuthor   = "dramaqu.ad"
+)
+
+// FeedService builds RSS/Atom feeds of new episodes
+type FeedService struct {
+	animeTerbaru *AnimeTerbaruService
+	detail       *DetailService
+	catalog      *catalog.Catalog
+}
+
+// NewFeedService creates a new FeedService
+func NewFeedService(animeTerbaru *AnimeTerbaruService, detail *DetailService, cat *catalog.Catalog) *FeedService {
+	return &FeedService{animeTerbaru: animeTerbaru, detail: detail, catalog: cat}
+}
+
+// Ongoing returns the feed of the latest episode of every ongoing drama.
+// GUID item adalah URL episode, sehingga episode yang sama tidak muncul dua kali
+// dan sama dengan GUID di feed per drama.
+func (s *FeedService) Ongoing(ctx context.Context) (*feed.Feed, error) {
+	ongoing, err := s.animeTerbaru.GetAnimeTerbaru(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	ongoingURL := s.animeTerbaru.ongoingURL()
+	result := &feed.Feed{
+		ID:          ongoingURL,
+		Title:       "DramaQu - Drama Ongoing",
+		Link:        ongoingURL,
+		Description: "Episode terbaru drama ongoing di dramaqu.ad",
+		Language:    feedLanguage,
+		Author:      feedAuthor,
+		Items:       make([]feed.Item, 0, len(ongoing.Data)),
+	}
+	for _, entry := range ongoing.Data {
+		if entry.URL == "" {
+			continue
+		}
+		result.Items = append(result.Items, s.ongoingItem(entry))
+	}
+	return result, nil
+}
+
+// ongoingItem builds the feed item of the latest episode of an ongoing drama
+func (s *FeedService) ongoingItem(entry models.DramaEntry) feed.Item {
+	episodeNum := parseEpisodeNumber(entry.Episode)
+	link := entry.URL
+	if episodeNum > 0 {
+		link = episodeURL(entry.URL, episodeNum)
+	}
+	return feed.Item{
+		GUID:        link,
+		Title:       episodeTitle(entry.Judul, episodeNum, strings.TrimSpace(entry.Episode)),
+		Link:        link,
+		Description: episodeDescription(entry.Judul, episodeNum),
+		Published:   s.publishedAt(link),
+		Image:       entry.Cover,
+	}
+}
+
+// publishedAt returns the release date of an episode taken from the source page,
+// zero jika belum diketahui. Waktu pertama kali terlihat di catalog tidak dipakai
+// karena berubah setiap kali server restart.
+func (s *FeedService) publishedAt(episodeURL string) time.Time {
+	episode, _ := s.catalog.Episode(episodeURL)
+	return episode.PublishedAt
+}
+
+// Drama returns the feed of all episodes of a drama, newest first
+func (s *FeedService) Drama(ctx context.Context, slug string) (*feed.Feed, error) {
+	detail, err := s.detail.GetDetailDrama(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &feed.Feed{
+		ID:          detail.URL,
+		Title:       "DramaQu - " + detail.Judul,
+		Link:        detail.URL,
+		Description: detail.Sinopsis,
+		Language:    feedLanguage,
+		Author:      feedAuthor,
+		Image:       detail.Cover,
+		Items:       make([]feed.Item, 0, len(detail.EpisodeList)),
+	}
+	// Urut dari nomor episode terbesar; tanggal rilis tidak selalu diketahui
+	episodes := append([]models.EpisodeItem(nil), detail.EpisodeList...)
+	sort.SliceStable(episodes, func(i, j int) bool {
+		return parseEpisodeNumber(episodes[i].Episode) > parseEpisodeNumber(episodes[j].Episode)
+	})
+	for _, episode := range episodes {
+		if episode.URL == "" {
+			continue
+		}
+		episodeNum := parseEpisodeNumber(episode.Episode)
+		// Catalog sudah diisi tanggal dari halaman sumber oleh DetailService
+		result.Items = append(result.Items, feed.Item{
+			GUID:        episode.URL,
+			Title:       episodeTitle(detail.Judul, episodeNum, strings.TrimSpace(episode.Title)),
+			Link:        episode.URL,
+			Description: episodeDescription(detail.Judul, episodeNum),
+			Published:   s.publishedAt(episode.URL),
+			Image:       detail.Cover,
+		})
+	}
+	return result, nil
+}
+
+// episodeTitle formats an item title such as "Judul - Episode 12"
+func episodeTitle(title string, episodeNum int, fallback string) string {
+	switch {
+	case episodeNum > 0:
+		return fmt.Sprintf("%s - Episode %d", title, episodeNum)
+	case fallback != "":
+		return title + " - " + fallback
+	}
+	return title
+}
+
+// episodeDescription describes a new episode in the site's language
+func episodeDescription(title string, episodeNum int) string {
+	if episodeNum > 0 {
+		return fmt.Sprintf("Episode %d dari %s sudah tersedia.", episodeNum, title)
+	}
+	return title + " sudah tersedia."
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nabilulilalbab/dramaqu/catalog"
+	"github.com/nabilulilalbab/dramaqu/models"
+)
+
+func TestOngoingItemUsesOnlyKnownPublishDates(t *testing.T) {
+	cat := catalog.New()
+	s := NewFeedService(nil, nil, cat)
+	entry := models.DramaEntry{Judul: "Judul Drama", URL: "https://dramaqu.ad/judul-drama/", Episode: "Episode 3"}
+
+	// Waktu pertama kali terlihat berubah setiap restart, jadi tidak dipakai sebagai pubDate
+	cat.RecordEpisode("https://dramaqu.ad/judul-drama/3/", time.Time{})
+	item := s.ongoingItem(entry)
+	if item.Link != "https://dramaqu.ad/judul-drama/3/" || !item.Published.IsZero() {
+		t.Errorf("item = %+v, want episode link without publish date", item)
+	}
+
+	published := time.Date(2026, 10, 19, 20, 0, 0, 0, siteLocation)
+	cat.RecordEpisode("https://dramaqu.ad/judul-drama/3/", published)
+	if item := s.ongoingItem(entry); !item.Published.Equal(published) {
+		t.Errorf("Published = %v, want %v", item.Published, published)
+	}
+}
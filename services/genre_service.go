@@ -0,0 +1,418 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"log"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly/v2"
+	"github.com/nabilulilalbab/dramaqu/catalog"
+	"github.com/nabilulilalbab/dramaqu/models"
+)
+
+// GenreService handles genre (category) listing and browsing
+type GenreService struct {
+	catalog *catalog.Catalog
+	site    Site
+}
+
+// NewGenreService creates a new instance of GenreService
+func NewGenreService(cat *catalog.Catalog, site Site) *GenreService {
+	return &GenreService{catalog: cat, site: site}
+}
+
+// wpCategory represents a category returned by the WordPress REST API
+type wpCategory struct {
+	Name  string `json:"name"`
+	Slug  string `json:"slug"`
+	Link  string `json:"link"`
+	Count int    `json:"count"`
+}
+
+// GetGenres returns all known genres with their post counts.
+// Data diambil dari WordPress REST API, dengan fallback ke link kategori di halaman utama.
+func (s *GenreService) GetGenres(ctx context.Context) (*models.GenreListResponse, error) {
+	baseURL := s.site.BaseURL
+
+	response := &models.GenreListResponse{
+		ConfidenceScore: 0.0, // Will be calculated later
+		Message:         "Data berhasil diambil",
+		Source:          "dramaqu.ad",
+		Data:            []models.GenreItem{},
+	}
+
+	genres := make(map[string]models.GenreItem)
+
+	c := colly.NewCollector(
+		colly.AllowedDomains(s.site.Domains()...),
+		colly.StdlibContext(ctx),
+		colly.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.0.0 Safari/537.36"),
+	)
+	c.SetRequestTimeout(30 * time.Second)
+	s.site.guard(c)
+
+	c.OnResponse(func(r *colly.Response) {
+		if !strings.Contains(r.Request.URL.Path, "/wp-json/") {
+			return
+		}
+		if err := s.parseWPCategories(r.Body, genres); err != nil {
+			log.Printf("Gagal mem-parsing JSON kategori: %v", err)
+			return
+		}
+
+		// Ambil halaman berikutnya jika kategori lebih dari satu halaman
+		if r.Request.URL.Query().Get("page") == "1" {
+			totalPages, _ := strconv.Atoi(r.Headers.Get("X-WP-TotalPages"))
+			for p := 2; p <= totalPages; p++ {
+				c.Visit(fmt.Sprintf("%s/wp-json/wp/v2/categories?per_page=100&page=%d", baseURL, p))
+			}
+		}
+	})
+
+	c.OnRequest(func(r *colly.Request) {
+		log.Println("Mengunjungi:", r.URL.String())
+	})
+
+	c.OnError(func(r *colly.Response, err error) {
+		log.Printf("Error saat request ke %s: %v", r.Request.URL, err)
+	})
+
+	if err := c.Visit(baseURL + "/wp-json/wp/v2/categories?per_page=100&page=1"); err != nil {
+		log.Printf("Gagal mengunjungi REST API kategori: %v", err)
+	}
+	c.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Fallback: kumpulkan link kategori dari halaman utama
+	if len(genres) == 0 {
+		fallback := colly.NewCollector(
+			colly.AllowedDomains(s.site.Domains()...),
+			colly.StdlibContext(ctx),
+			colly.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.0.0 Safari/537.36"),
+		)
+		fallback.SetRequestTimeout(30 * time.Second)
+		s.site.guard(fallback)
+
+		fallback.OnHTML("body", func(e *colly.HTMLElement) {
+			s.parseCategoryLinks(e.DOM, e.Request.URL, genres)
+		})
+
+		fallback.OnError(func(r *colly.Response, err error) {
+			log.Printf("Error saat request ke %s: %v", r.Request.URL, err)
+		})
+
+		if err := visit(ctx, fallback, baseURL+"/"); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, item := range genres {
+		if item.Name == "" {
+			continue
+		}
+		response.Data = append(response.Data, item)
+	}
+	sort.Slice(response.Data, func(i, j int) bool {
+		return strings.ToLower(response.Data[i].Name) < strings.ToLower(response.Data[j].Name)
+	})
+
+	// Calculate confidence score based on data completeness
+	response.ConfidenceScore = s.calculateConfidenceScore(response)
+
+	// Update message based on confidence score
+	if response.ConfidenceScore == 0.0 {
+		response.Message = "Data tidak lengkap - field wajib tidak ada"
+	} else if response.ConfidenceScore < 0.5 {
+		response.Message = "Data berhasil diambil dengan kelengkapan rendah"
+	} else if response.ConfidenceScore < 1.0 {
+		response.Message = "Data berhasil diambil dengan kelengkapan sedang"
+	} else {
+		response.Message = "Data berhasil diambil dengan kelengkapan sempurna"
+	}
+
+	return response, nil
+}
+
+// GetDramasByGenre scrapes and returns dramas listed on a genre category page
+func (s *GenreService) GetDramasByGenre(ctx context.Context, genreSlug string, page int) (*models.DramaListResponse, error) {
+	// Build target URL based on page number
+	baseURL := s.site.URL(fmt.Sprintf("/category/%s/", genreSlug))
+	targetURL := baseURL
+	if page > 1 {
+		targetURL = fmt.Sprintf("%spage/%d/", baseURL, page)
+	}
+
+	response := &models.DramaListResponse{
+		ConfidenceScore: 0.0, // Will be calculated later
+		Message:         "Data berhasil diambil",
+		Source:          "dramaqu.ad",
+		Data:            []models.DramaDetail{},
+	}
+
+	c := colly.NewCollector(
+		colly.AllowedDomains(s.site.Domains()...),
+		colly.StdlibContext(ctx),
+		colly.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.0.0 Safari/537.36"),
+	)
+	c.SetRequestTimeout(30 * time.Second)
+	s.site.guard(c)
+
+	// Nama genre diambil dari judul halaman kategori, fallback ke slug
+	genreName := s.nameFromSlug(genreSlug)
+	c.OnHTML("h1.page-title, div.film-content h2.title span", func(e *colly.HTMLElement) {
+		if name := strings.TrimSpace(e.Text); name != "" {
+			genreName = name
+		}
+	})
+
+	reViews := regexp.MustCompile(`[0-9,]+`)
+
+	c.OnHTML("article.movie-preview", func(e *colly.HTMLElement) {
+		entry := models.DramaDetail{}
+
+		titleElement := e.DOM.Find("span.movie-title a")
+		entry.Judul = titleElement.Text()
+		entry.URL = titleElement.AttrOr("href", "")
+
+		if parsedURL, err := url.Parse(entry.URL); err == nil {
+			entry.Slug = path.Base(strings.TrimSuffix(parsedURL.Path, "/"))
+		}
+
+		entry.Cover = e.DOM.Find("img.keremiya-image").AttrOr("src", "")
+		entry.Sinopsis = e.DOM.Find("p.story").Text()
+		entry.Tanggal = e.DOM.Find("span.movie-release").Text()
+		entry.Views = reViews.FindString(e.DOM.Find("span.views").Text())
+
+		// Menentukan Status berdasarkan Teks Episode
+		if e.DOM.Find("span.icon-hd").Text() != "" {
+			entry.Status = "Ongoing"
+		} else {
+			entry.Status = "Completed"
+		}
+		entry.Skor = "N/A"
+
+		response.Data = append(response.Data, entry)
+	})
+
+	c.OnRequest(func(r *colly.Request) {
+		log.Println("Mengunjungi:", r.URL.String())
+	})
+
+	c.OnError(func(r *colly.Response, err error) {
+		log.Printf("Error saat request ke %s: %v", r.Request.URL, err)
+	})
+
+	if err := visit(ctx, c, targetURL); err != nil {
+		return nil, err
+	}
+
+	// Simpan hasil scraping ke catalog untuk saran pencarian
+	for _, item := range response.Data {
+		s.catalog.UpsertDrama(catalog.Drama{Slug: item.Slug, Title: item.Judul, URL: item.URL, Cover: item.Cover})
+	}
+
+	// Semua item pada halaman kategori memiliki genre yang sama
+	for i := range response.Data {
+		response.Data[i].Genres = []string{genreName}
+	}
+
+	// Calculate confidence score based on data completeness
+	response.ConfidenceScore = s.calculateDramaListConfidenceScore(response)
+
+	// Update message based on confidence score
+	if response.ConfidenceScore == 0.0 {
+		response.Message = "Data tidak lengkap - field wajib tidak ada"
+	} else if response.ConfidenceScore < 0.5 {
+		response.Message = "Data berhasil diambil dengan kelengkapan rendah"
+	} else if response.ConfidenceScore < 1.0 {
+		response.Message = "Data berhasil diambil dengan kelengkapan sedang"
+	} else {
+		response.Message = "Data berhasil diambil dengan kelengkapan sempurna"
+	}
+
+	return response, nil
+}
+
+// nonGenreCategories lists category slugs that describe a status or type instead of a genre.
+// Dipakai oleh REST API maupun fallback halaman utama agar keduanya menghasilkan daftar yang sama.
+var nonGenreCategories = map[string]bool{
+	"uncategorized":   true,
+	"ongoing":         true,
+	"ongoing-drama":   true,
+	"completed":       true,
+	"completed-drama": true,
+	"tamat":           true,
+	"movie":           true,
+	"movies":          true,
+}
+
+// isGenreSlug reports whether a category slug is a genre
+func isGenreSlug(slug string) bool {
+	return slug != "" && !nonGenreCategories[slug]
+}
+
+// reCategoryCount matches the post count next to a category link, contoh: "Action (120)"
+var reCategoryCount = regexp.MustCompile(`\((\d+)\)`)
+
+// parseWPCategories adds the categories of a WordPress REST API response to genres
+func (s *GenreService) parseWPCategories(body []byte, genres map[string]models.GenreItem) error {
+	var categories []wpCategory
+	if err := json.Unmarshal(body, &categories); err != nil {
+		return err
+	}
+	for _, cat := range categories {
+		if !isGenreSlug(cat.Slug) {
+			continue
+		}
+		genres[cat.Slug] = models.GenreItem{
+			Name:  html.UnescapeString(cat.Name),
+			Slug:  cat.Slug,
+			URL:   cat.Link,
+			Count: cat.Count,
+		}
+	}
+	return nil
+}
+
+// parseCategoryLinks adds the genre category links on a page to genres (fallback tanpa REST API).
+// Link relatif di-resolve terhadap pageURL; kategori status dan tipe dilewati.
+func (s *GenreService) parseCategoryLinks(doc *goquery.Selection, pageURL *url.URL, genres map[string]models.GenreItem) {
+	doc.Find("a[href*='/category/']").Each(func(_ int, a *goquery.Selection) {
+		href, err := pageURL.Parse(a.AttrOr("href", ""))
+		if err != nil {
+			return
+		}
+		genreURL := href.String()
+		slug := s.slugFromCategoryURL(genreURL)
+		if !isGenreSlug(slug) {
+			return
+		}
+		text := a.Text()
+		item := genres[slug]
+		item.Slug = slug
+		item.URL = genreURL
+		if item.Name == "" {
+			item.Name = strings.TrimSpace(reCategoryCount.ReplaceAllString(text, ""))
+		}
+		// Widget kategori WordPress menampilkan jumlah post di samping link, contoh: "Action (120)"
+		countText := text + " " + a.Parent().Text()
+		if m := reCategoryCount.FindStringSubmatch(countText); len(m) > 1 {
+			if count, err := strconv.Atoi(m[1]); err == nil && count > item.Count {
+				item.Count = count
+			}
+		}
+		genres[slug] = item
+	})
+}
+
+// slugFromCategoryURL extracts the category slug from a category URL
+func (s *GenreService) slugFromCategoryURL(categoryURL string) string {
+	parsedURL, err := url.Parse(categoryURL)
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(strings.Trim(parsedURL.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "category" {
+		return ""
+	}
+	// Sub-kategori menggunakan bagian terakhir dari path, contoh: /category/genre/action/
+	if parts[len(parts)-2] == "page" {
+		parts = parts[:len(parts)-2]
+	}
+	return parts[len(parts)-1]
+}
+
+// nameFromSlug converts a slug into a human readable name
+func (s *GenreService) nameFromSlug(slug string) string {
+	words := strings.Split(slug, "-")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// calculateConfidenceScore calculates confidence score for genre list response
+func (s *GenreService) calculateConfidenceScore(response *models.GenreListResponse) float64 {
+	if len(response.Data) == 0 {
+		return 0.0
+	}
+
+	totalItems := len(response.Data)
+	validItems := 0.0
+
+	for _, item := range response.Data {
+		if !s.hasRequiredFields(item.Name, item.Slug, item.URL) {
+			continue
+		}
+		// Optional field: Count
+		if item.Count > 0 {
+			validItems += 1.0
+		} else {
+			validItems += 0.5
+		}
+	}
+
+	score := validItems / float64(totalItems)
+
+	// Round to 2 decimal places
+	return float64(int(score*100)) / 100
+}
+
+// calculateDramaListConfidenceScore calculates confidence score for genre browse response
+func (s *GenreService) calculateDramaListConfidenceScore(response *models.DramaListResponse) float64 {
+	if len(response.Data) == 0 {
+		return 0.0
+	}
+
+	totalItems := len(response.Data)
+	validItems := 0.0
+
+	for _, item := range response.Data {
+		if s.isDramaDetailValid(item) {
+			validItems += 1.0
+		} else if s.hasRequiredFields(item.Judul, item.URL, item.Slug, item.Cover) {
+			// If required fields exist but optional fields missing, count as partial
+			validItems += 0.5
+		}
+	}
+
+	score := validItems / float64(totalItems)
+
+	// Round to 2 decimal places
+	return float64(int(score*100)) / 100
+}
+
+// isDramaDetailValid checks if DramaDetail has all required and optional fields
+func (s *GenreService) isDramaDetailValid(item models.DramaDetail) bool {
+	// Required fields: Judul, URL, Slug, Cover
+	if !s.hasRequiredFields(item.Judul, item.URL, item.Slug, item.Cover) {
+		return false
+	}
+
+	// Optional fields: Status, Sinopsis, Views, Genres, Tanggal
+	return s.hasRequiredFields(item.Status, item.Sinopsis, item.Views, item.Tanggal) && len(item.Genres) > 0
+}
+
+// hasRequiredFields checks if all required fields are present and not empty
+func (s *GenreService) hasRequiredFields(fields ...string) bool {
+	for _, field := range fields {
+		if strings.TrimSpace(field) == "" {
+			return false
+		}
+	}
+	return true
+}
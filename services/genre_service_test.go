@@ -0,0 +1,80 @@
+package services
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/nabilulilalbab/dramaqu/models"
+)
+
+func TestParseWPCategories(t *testing.T) {
+	s := &GenreService{}
+	genres := make(map[string]models.GenreItem)
+	if err := s.parseWPCategories([]byte(loadFixture(t, "genre_categories.json")), genres); err != nil {
+		t.Fatalf("parseWPCategories() error = %v", err)
+	}
+
+	want := map[string]models.GenreItem{
+		"action":   {Name: "Action", Slug: "action", URL: "https://dramaqu.ad/category/action/", Count: 120},
+		"rom-com":  {Name: "Rom & Com", Slug: "rom-com", URL: "https://dramaqu.ad/category/genre/rom-com/", Count: 45},
+		"thriller": {Name: "Thriller", Slug: "thriller", URL: "https://dramaqu.ad/category/genre/thriller/", Count: 33},
+	}
+	if !reflect.DeepEqual(genres, want) {
+		t.Errorf("genres = %+v, want %+v", genres, want)
+	}
+
+	if err := s.parseWPCategories([]byte(`{"code":"rest_no_route"}`), genres); err == nil {
+		t.Error("parseWPCategories(error object) succeeded, want error")
+	}
+}
+
+func TestParseCategoryLinks(t *testing.T) {
+	s := &GenreService{}
+	pageURL, _ := url.Parse("https://dramaqu.ad/")
+	genres := make(map[string]models.GenreItem)
+	s.parseCategoryLinks(mustParseDoc(t, loadFixture(t, "genre_home.html")), pageURL, genres)
+
+	want := map[string]models.GenreItem{
+		// Jumlah post diambil dari teks di samping link maupun di dalam link
+		"action":   {Name: "Action", Slug: "action", URL: "https://dramaqu.ad/category/action/", Count: 120},
+		"thriller": {Name: "Thriller", Slug: "thriller", URL: "https://dramaqu.ad/category/genre/thriller/", Count: 33},
+		"rom-com":  {Name: "Rom & Com", Slug: "rom-com", URL: "https://dramaqu.ad/category/genre/rom-com/", Count: 45},
+	}
+	if !reflect.DeepEqual(genres, want) {
+		t.Errorf("genres = %+v, want %+v", genres, want)
+	}
+}
+
+func TestFallbackMatchesWPCategories(t *testing.T) {
+	s := &GenreService{}
+	fromAPI := make(map[string]models.GenreItem)
+	if err := s.parseWPCategories([]byte(loadFixture(t, "genre_categories.json")), fromAPI); err != nil {
+		t.Fatalf("parseWPCategories() error = %v", err)
+	}
+	pageURL, _ := url.Parse("https://dramaqu.ad/")
+	fromHome := make(map[string]models.GenreItem)
+	s.parseCategoryLinks(mustParseDoc(t, loadFixture(t, "genre_home.html")), pageURL, fromHome)
+
+	// Kategori status seperti ongoing-drama dan completed tidak termasuk genre
+	if !reflect.DeepEqual(fromHome, fromAPI) {
+		t.Errorf("fallback genres = %+v, REST API genres = %+v", fromHome, fromAPI)
+	}
+}
+
+func TestSlugFromCategoryURL(t *testing.T) {
+	s := &GenreService{}
+	tests := map[string]string{
+		"https://dramaqu.ad/category/action/":             "action",
+		"https://dramaqu.ad/category/genre/rom-com/":      "rom-com",
+		"https://dramaqu.ad/category/action/page/3/":      "action",
+		"https://dramaqu.ad/category/":                    "",
+		"https://dramaqu.ad/nonton-judul-drama/":          "",
+		"https://dramaqu.ad/tag/category/bukan-kategori/": "",
+	}
+	for categoryURL, want := range tests {
+		if got := s.slugFromCategoryURL(categoryURL); got != want {
+			t.Errorf("slugFromCategoryURL(%q) = %q, want %q", categoryURL, got, want)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nabilulilalbab/dramaqu/catalog"
+	"github.com/nabilulilalbab/dramaqu/models"
+	"github.com/nabilulilalbab/dramaqu/urlguard"
+)
+
+const (
+	// healthProbeTimeout limits one HEAD/GET probe
+	healthProbeTimeout = 10 * time.Second
+	// healthProbeConcurrency limits how many servers are probed at once
+	healthProbeConcurrency = 8
+	// healthProbeBytes is how much of the body a fallback GET reads
+	healthProbeBytes = 1024
+	// serverRetention drops servers no longer seen on any episode page
+	serverRetention = 7 * 24 * time.Hour
+)
+
+// HealthCheckService probes streaming servers and records their health in the catalog
+type HealthCheckService struct {
+	catalog  *catalog.Catalog
+	site     Site
+	client   *http.Client
+	interval time.Duration
+}
+
+// NewHealthCheckService creates a new instance of HealthCheckService.
+// interval 0 menonaktifkan pengecekan berkala di background.
+func NewHealthCheckService(cat *catalog.Catalog, site Site, interval time.Duration) *HealthCheckService {
+	return &HealthCheckService{
+		catalog:  cat,
+		site:     site,
+		client:   urlguard.NewClient(healthProbeTimeout),
+		interval: interval,
+	}
+}
+
+// Start runs the periodic health check until ctx is cancelled
+func (s *HealthCheckService) Start(ctx context.Context) {
+	if s.interval <= 0 {
+		log.Println("Health check server streaming dinonaktifkan")
+		return
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.CheckAll(ctx)
+		}
+	}
+}
+
+// CheckAll probes every streaming server known to the catalog
+func (s *HealthCheckService) CheckAll(ctx context.Context) {
+	if removed := s.catalog.PruneServers(time.Now().Add(-serverRetention)); removed > 0 {
+		log.Printf("Menghapus %d server streaming yang sudah lama tidak terlihat", removed)
+	}
+
+	servers := s.catalog.Servers()
+	urls := make([]string, 0, len(servers))
+	for _, server := range servers {
+		urls = append(urls, server.URL)
+	}
+
+	start := time.Now()
+	s.checkURLs(ctx, urls)
+	log.Printf("Health check %d server streaming selesai dalam %s", len(urls), time.Since(start).Round(time.Millisecond))
+}
+
+// CheckUnknown probes the servers of an episode that have never been checked
+func (s *HealthCheckService) CheckUnknown(ctx context.Context, detail *models.EpisodeDetailResponse) {
+	var urls []string
+	for _, server := range detail.StreamingServers {
+		if server.Health.Status == catalog.HealthUnknown {
+			urls = append(urls, server.StreamingURL)
+		}
+	}
+	if len(urls) == 0 {
+		return
+	}
+	s.checkURLs(ctx, urls)
+	ApplyServerHealth(s.catalog, detail)
+}
+
+// FilterAlive removes servers that are not alive from an episode response
+func (s *HealthCheckService) FilterAlive(detail *models.EpisodeDetailResponse) {
+	alive := []models.StreamingServer{}
+	for _, server := range detail.StreamingServers {
+		if server.Health.Status == catalog.HealthAlive {
+			alive = append(alive, server)
+		}
+	}
+	detail.StreamingServers = alive
+}
+
+// checkURLs probes urls with bounded concurrency.
+// Probe yang gagal karena ctx dibatalkan (client disconnect, X-Request-Timeout) tidak
+// dicatat, agar server yang sehat tidak ditandai dead di catalog bersama.
+func (s *HealthCheckService) checkURLs(ctx context.Context, urls []string) {
+	sem := make(chan struct{}, healthProbeConcurrency)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for _, serverURL := range urls {
+		select {
+		case <-ctx.Done():
+			return
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(serverURL string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			status, code, latency, err := s.probe(ctx, serverURL)
+			if ctx.Err() != nil {
+				return
+			}
+			errMessage := ""
+			if err != nil {
+				errMessage = err.Error()
+			}
+			s.catalog.RecordServerHealth(serverURL, status, code, latency, errMessage)
+		}(serverURL)
+	}
+}
+
+// probe sends a HEAD request and falls back to a small ranged GET when the
+// host does not support HEAD. Status 2xx/3xx dianggap alive.
+func (s *HealthCheckService) probe(ctx context.Context, serverURL string) (string, int, time.Duration, error) {
+	start := time.Now()
+	code, err := s.request(ctx, http.MethodHead, serverURL)
+	if err != nil || code == http.StatusMethodNotAllowed || code == http.StatusNotImplemented || code == http.StatusForbidden {
+		start = time.Now()
+		code, err = s.request(ctx, http.MethodGet, serverURL)
+	}
+	latency := time.Since(start)
+
+	if err != nil {
+		return catalog.HealthDead, 0, latency, err
+	}
+	if code >= http.StatusBadRequest {
+		return catalog.HealthDead, code, latency, fmt.Errorf("status %d", code)
+	}
+	return catalog.HealthAlive, code, latency, nil
+}
+
+// request performs one probe request and returns the status code
+func (s *HealthCheckService) request(ctx context.Context, method, serverURL string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, serverURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	// Sebagian besar host embed menolak request tanpa Referer dari situs asal
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.0.0 Safari/537.36")
+	req.Header.Set("Referer", s.site.Referer())
+	if method == http.MethodGet {
+		req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", healthProbeBytes-1))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, healthProbeBytes))
+	return resp.StatusCode, nil
+}
+
+// ApplyServerHealth copies catalog health into an episode response and sorts the
+// servers by health: alive (tercepat dulu), belum dicek, lalu mati.
+func ApplyServerHealth(cat *catalog.Catalog, detail *models.EpisodeDetailResponse) {
+	latency := make(map[string]time.Duration)
+	for i := range detail.StreamingServers {
+		server := &detail.StreamingServers[i]
+		health := models.ServerHealth{Status: catalog.HealthUnknown}
+		if known, ok := cat.Server(server.StreamingURL); ok {
+			health.Status = known.Status
+			health.StatusCode = known.StatusCode
+			health.Error = known.Error
+			if !known.LastChecked.IsZero() {
+				health.LatencyMS = known.Latency.Milliseconds()
+				health.LastChecked = known.LastChecked.Format(time.RFC3339)
+			}
+			latency[server.ServerID] = known.Latency
+		}
+		server.Health = health
+	}
+
+	sort.SliceStable(detail.StreamingServers, func(i, j int) bool {
+		a, b := detail.StreamingServers[i], detail.StreamingServers[j]
+		rankA, rankB := catalog.HealthRank(a.Health.Status), catalog.HealthRank(b.Health.Status)
+		if rankA != rankB {
+			return rankA < rankB
+		}
+		if a.Health.Status == catalog.HealthAlive {
+			return latency[a.ServerID] < latency[b.ServerID]
+		}
+		return false
+	})
+}
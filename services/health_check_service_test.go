@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nabilulilalbab/dramaqu/catalog"
+	"github.com/nabilulilalbab/dramaqu/models"
+)
+
+func newHealthTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/alive":
+			w.WriteHeader(http.StatusOK)
+		case "/no-head":
+			// Host yang menolak HEAD tetapi melayani GET dengan Range
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			if r.Header.Get("Range") == "" {
+				t.Errorf("fallback GET must be ranged")
+			}
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write([]byte("#EXTM3U"))
+		case "/slow":
+			time.Sleep(30 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestHealthCheckProbe(t *testing.T) {
+	srv := newHealthTestServer(t)
+	s := NewHealthCheckService(catalog.New(), testSite, 0)
+	// Server test berada di 127.0.0.1 yang ditolak client urlguard
+	s.client = srv.Client()
+
+	cases := map[string]struct {
+		status string
+		code   int
+	}{
+		"/alive":   {catalog.HealthAlive, http.StatusOK},
+		"/no-head": {catalog.HealthAlive, http.StatusPartialContent},
+		"/gone":    {catalog.HealthDead, http.StatusNotFound},
+	}
+	for path, want := range cases {
+		status, code, _, _ := s.probe(context.Background(), srv.URL+path)
+		if status != want.status || code != want.code {
+			t.Errorf("probe(%s) = %s %d, want %s %d", path, status, code, want.status, want.code)
+		}
+	}
+
+	status, _, _, err := s.probe(context.Background(), "http://127.0.0.1:1/refused")
+	if status != catalog.HealthDead || err == nil {
+		t.Errorf("unreachable server must be dead with an error, got %s %v", status, err)
+	}
+}
+
+func TestCheckAllRecordsHealthAndSortsServers(t *testing.T) {
+	srv := newHealthTestServer(t)
+	cat := catalog.New()
+	s := NewHealthCheckService(cat, testSite, 0)
+	s.client = srv.Client()
+
+	detail := &models.EpisodeDetailResponse{StreamingServers: []models.StreamingServer{
+		{ServerID: "dead", StreamingURL: srv.URL + "/gone"},
+		{ServerID: "new", StreamingURL: srv.URL + "/never-checked"},
+		{ServerID: "slow", StreamingURL: srv.URL + "/slow"},
+		{ServerID: "fast", StreamingURL: srv.URL + "/alive"},
+	}}
+	for _, server := range detail.StreamingServers {
+		if server.ServerID != "new" {
+			cat.TrackServer(server.StreamingURL, "")
+		}
+	}
+
+	s.CheckAll(context.Background())
+	known, ok := cat.Server(srv.URL + "/alive")
+	if !ok || known.Status != catalog.HealthAlive || known.LastChecked.IsZero() {
+		t.Fatalf("health not recorded in catalog: %+v", known)
+	}
+
+	ApplyServerHealth(cat, detail)
+	var order []string
+	for _, server := range detail.StreamingServers {
+		order = append(order, server.ServerID)
+	}
+	want := []string{"fast", "slow", "new", "dead"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("server order = %v, want %v", order, want)
+		}
+	}
+	if detail.StreamingServers[0].Health.LastChecked == "" || detail.StreamingServers[2].Health.Status != catalog.HealthUnknown {
+		t.Errorf("health not applied: %+v", detail.StreamingServers)
+	}
+
+	// only_alive: server yang belum dicek diperiksa dulu, lalu yang mati dibuang
+	s.CheckUnknown(context.Background(), detail)
+	s.FilterAlive(detail)
+	if len(detail.StreamingServers) != 2 {
+		t.Errorf("FilterAlive kept %+v, want the two alive servers", detail.StreamingServers)
+	}
+}
+
+func TestCancelledCheckKeepsHealthUnknown(t *testing.T) {
+	srv := newHealthTestServer(t)
+	cat := catalog.New()
+	s := NewHealthCheckService(cat, testSite, 0)
+	s.client = srv.Client()
+
+	urls := []string{srv.URL + "/alive", srv.URL + "/slow"}
+	for _, serverURL := range urls {
+		cat.TrackServer(serverURL, "")
+	}
+
+	// Request yang sudah dibatalkan maupun yang habis waktunya di tengah probe
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.checkURLs(cancelled, urls)
+	short, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	s.checkURLs(short, urls[1:])
+
+	for _, serverURL := range urls {
+		if known, _ := cat.Server(serverURL); known.Status != catalog.HealthUnknown || !known.LastChecked.IsZero() {
+			t.Errorf("%s: health = %+v, want unknown after a cancelled check", serverURL, known)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+	"github.com/nabilulilalbab/dramaqu/models"
+)
+
+// homeFetchConcurrency limits how many home pages are scraped at once
+const homeFetchConcurrency = 2
+
+// Sections of the home response, reported in FinalResponse.Sections
+const (
+	HomeSectionTop10       = "top10"
+	HomeSectionNewEps      = "new_eps"
+	HomeSectionMovies      = "movies"
+	HomeSectionJadwalRilis = "jadwal_rilis"
+)
+
+// Section statuses
+const (
+	SectionStatusOK    = "ok"
+	SectionStatusEmpty = "empty"
+	SectionStatusError = "error"
+)
+
+// homePage is one upstream page that fills one or more home sections.
+// setup mendaftarkan callback colly yang mengisi section tersebut.
+type homePage struct {
+	url      string
+	sections []string
+	setup    func(c *colly.Collector)
+}
+
+// homePageResult is the outcome of scraping one homePage
+type homePageResult struct {
+	err      error
+	duration time.Duration
+}
+
+// fetchPages scrapes every page concurrently (dibatasi homeFetchConcurrency)
+// and returns one result per page in the same order
+func (s *HomeService) fetchPages(ctx context.Context, pages []homePage) []homePageResult {
+	results := make([]homePageResult, len(pages))
+	sem := make(chan struct{}, homeFetchConcurrency)
+	var wg sync.WaitGroup
+	for i, page := range pages {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, page homePage) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			start := time.Now()
+			err := s.fetchPage(ctx, page)
+			results[i] = homePageResult{err: err, duration: time.Since(start)}
+			if err != nil {
+				log.Printf("Section %v gagal setelah %s: %v", page.sections, results[i].duration.Round(time.Millisecond), err)
+				return
+			}
+			log.Printf("Section %v selesai dalam %s", page.sections, results[i].duration.Round(time.Millisecond))
+		}(i, page)
+	}
+	wg.Wait()
+	return results
+}
+
+// fetchPage scrapes a single page with its own collector
+func (s *HomeService) fetchPage(ctx context.Context, page homePage) error {
+	pageURL, err := url.Parse(page.url)
+	if err != nil {
+		return fmt.Errorf("URL section tidak valid: %w", err)
+	}
+
+	c := colly.NewCollector(
+		colly.AllowedDomains(pageURL.Hostname()),
+		colly.StdlibContext(ctx),
+	)
+	c.SetRequestTimeout(30 * time.Second)
+	s.site.guard(c)
+	page.setup(c)
+
+	// Status non-2xx dari upstream juga dianggap gagal
+	return visit(ctx, c, page.url)
+}
+
+// sectionStatuses builds the per-section status of the home response
+func sectionStatuses(pages []homePage, results []homePageResult, items map[string]int) []models.SectionStatus {
+	statuses := []models.SectionStatus{}
+	for i, page := range pages {
+		for _, name := range page.sections {
+			status := models.SectionStatus{
+				Name:       name,
+				Status:     SectionStatusOK,
+				Source:     page.url,
+				Items:      items[name],
+				DurationMS: results[i].duration.Milliseconds(),
+			}
+			switch {
+			case results[i].err != nil:
+				status.Status = SectionStatusError
+				status.Error = results[i].err.Error()
+			case status.Items == 0:
+				status.Status = SectionStatusEmpty
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
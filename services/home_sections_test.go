@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+	"github.com/nabilulilalbab/dramaqu/catalog"
+)
+
+func TestFetchPagesReturnsPartialResults(t *testing.T) {
+	var inFlight, maxInFlight int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		if r.URL.Path == "/broken/" {
+			http.Error(w, "upstream error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><body><article class="movie-preview">a</article><article class="movie-preview">b</article></body></html>`))
+	}))
+	defer srv.Close()
+
+	counted := 0
+	countArticles := func(c *colly.Collector) {
+		c.OnHTML("article.movie-preview", func(*colly.HTMLElement) { counted++ })
+	}
+	pages := []homePage{
+		{url: srv.URL + "/", sections: []string{HomeSectionTop10, HomeSectionMovies}, setup: countArticles},
+		{url: srv.URL + "/broken/", sections: []string{HomeSectionJadwalRilis}, setup: func(*colly.Collector) {}},
+		{url: srv.URL + "/ongoing/", sections: []string{HomeSectionNewEps}, setup: func(*colly.Collector) {}},
+	}
+
+	s := NewHomeService(catalog.New(), localSite(srv.URL))
+	results := s.fetchPages(context.Background(), pages)
+
+	if results[0].err != nil || results[2].err != nil {
+		t.Fatalf("healthy pages failed: %v / %v", results[0].err, results[2].err)
+	}
+	if results[1].err == nil {
+		t.Fatal("a 500 page must be reported as failed")
+	}
+	if counted != 2 {
+		t.Errorf("parsed %d articles, want 2", counted)
+	}
+	if maxInFlight > homeFetchConcurrency {
+		t.Errorf("%d pages fetched at once, limit is %d", maxInFlight, homeFetchConcurrency)
+	}
+	if maxInFlight < 2 {
+		t.Errorf("pages were not fetched in parallel (max in flight %d)", maxInFlight)
+	}
+
+	statuses := sectionStatuses(pages, results, map[string]int{HomeSectionTop10: 2})
+	want := map[string]string{
+		HomeSectionTop10:       SectionStatusOK,
+		HomeSectionMovies:      SectionStatusEmpty,
+		HomeSectionJadwalRilis: SectionStatusError,
+		HomeSectionNewEps:      SectionStatusEmpty,
+	}
+	if len(statuses) != len(want) {
+		t.Fatalf("statuses = %+v", statuses)
+	}
+	for _, status := range statuses {
+		if status.Status != want[status.Name] {
+			t.Errorf("section %s status = %s, want %s", status.Name, status.Status, want[status.Name])
+		}
+		if status.Status == SectionStatusError && status.Error == "" {
+			t.Errorf("section %s failed without an error message", status.Name)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
@@ -8,83 +10,107 @@ import (
 	"time"
 
 	"github.com/gocolly/colly/v2"
+	"github.com/nabilulilalbab/dramaqu/catalog"
 	"github.com/nabilulilalbab/dramaqu/models"
 	"github.com/nabilulilalbab/dramaqu/scrape"
 )
 
 // HomeService handles home page data scraping
-type HomeService struct{}
+type HomeService struct {
+	catalog *catalog.Catalog
+	site    Site
+}
 
 // NewHomeService creates a new instance of HomeService
-func NewHomeService() *HomeService {
-	return &HomeService{}
+func NewHomeService(cat *catalog.Catalog, site Site) *HomeService {
+	return &HomeService{catalog: cat, site: site}
 }
 
 // GetHomeData scrapes and returns home page data with the exact same logic as the test
-func (s *HomeService) GetHomeData() (*models.FinalResponse, error) {
+func (s *HomeService) GetHomeData(ctx context.Context) (*models.FinalResponse, error) {
 	rand.Seed(time.Now().UnixNano())
 
 	finalResponse := &models.FinalResponse{
 		ConfidenceScore: 0.0, // Will be calculated later
 		Message:         "Data berhasil diambil",
 		Source:          "dramaqu.ad",
+		Top10:           []models.Top10Item{},
+		NewEps:          []models.NewEpsItem{},
+		Movies:          []models.MovieItem{},
 	}
 
-	c := colly.NewCollector(
-		colly.AllowedDomains("dramaqu.ad"),
-	)
-
-	c.OnHTML("div.film-content", func(e *colly.HTMLElement) {
-		sectionTitle := e.ChildText("h2.title span")
-		e.ForEach("article.movie-preview", func(_ int, item *colly.HTMLElement) {
-			switch sectionTitle {
-			case "Ongoing Drama":
-				if len(finalResponse.NewEps) < 20 {
-					itemData := s.parseNewEpsItem(item)
-					finalResponse.NewEps = append(finalResponse.NewEps, itemData)
-				}
-			case "Film Korea":
-				if len(finalResponse.Movies) < 20 {
-					itemData := s.parseMovieItem(item)
-					finalResponse.Movies = append(finalResponse.Movies, itemData)
-				}
-			case "Drama Populer":
-				if len(finalResponse.Top10) < 10 {
-					itemData := s.parseTop10Item(item)
-					finalResponse.Top10 = append(finalResponse.Top10, itemData)
-				}
-			}
-		})
-	})
-
-	log.Println("Memulai scraping halaman utama...")
-	err := c.Visit("https://dramaqu.ad/")
-	if err != nil {
-		return nil, fmt.Errorf("failed to visit main page: %v", err)
-	}
-	c.Wait()
-	log.Println("Scraping halaman utama selesai.")
-
-	scheduleCollector := colly.NewCollector(
-		colly.AllowedDomains("dramaqu.ad"),
-	)
 	var ongoingItemsForSchedule []models.JadwalItem
-	scheduleCollector.OnHTML("article.movie-preview", func(e *colly.HTMLElement) {
-		item := s.parseJadwalItem(e)
-		ongoingItemsForSchedule = append(ongoingItemsForSchedule, item)
-	})
+	pages := []homePage{
+		{
+			url:      s.site.URL("/"),
+			sections: []string{HomeSectionTop10, HomeSectionNewEps, HomeSectionMovies},
+			setup: func(c *colly.Collector) {
+				c.OnHTML("div.film-content", func(e *colly.HTMLElement) {
+					sectionTitle := e.ChildText("h2.title span")
+					e.ForEach("article.movie-preview", func(_ int, item *colly.HTMLElement) {
+						switch sectionTitle {
+						case "Ongoing Drama":
+							if len(finalResponse.NewEps) < 20 {
+								itemData := s.parseNewEpsItem(item)
+								finalResponse.NewEps = append(finalResponse.NewEps, itemData)
+							}
+						case "Film Korea":
+							if len(finalResponse.Movies) < 20 {
+								itemData := s.parseMovieItem(item)
+								finalResponse.Movies = append(finalResponse.Movies, itemData)
+							}
+						case "Drama Populer":
+							if len(finalResponse.Top10) < 10 {
+								itemData := s.parseTop10Item(item)
+								finalResponse.Top10 = append(finalResponse.Top10, itemData)
+							}
+						}
+					})
+				})
+			},
+		},
+		{
+			url:      s.site.URL(ongoingPath),
+			sections: []string{HomeSectionJadwalRilis},
+			setup: func(c *colly.Collector) {
+				c.OnHTML("article.movie-preview", func(e *colly.HTMLElement) {
+					item := s.parseJadwalItem(e)
+					ongoingItemsForSchedule = append(ongoingItemsForSchedule, item)
+				})
+			},
+		},
+	}
+
+	// Halaman diambil paralel; section yang gagal dilaporkan tanpa menggagalkan seluruh response
+	log.Println("Memulai scraping section halaman utama...")
+	results := s.fetchPages(ctx, pages)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, result := range results {
+		if result.err != nil {
+			errs = append(errs, result.err)
+		}
+	}
+	if len(errs) == len(pages) {
+		return nil, fmt.Errorf("semua section gagal diambil: %w", errors.Join(errs...))
+	}
 
-	log.Println("Memulai scraping halaman 'Ongoing' untuk data jadwal...")
-	err = scheduleCollector.Visit("https://dramaqu.ad/category/ongoing-drama/")
-	if err != nil {
-		return nil, fmt.Errorf("failed to visit ongoing page: %v", err)
+	items := map[string]int{
+		HomeSectionTop10:       len(finalResponse.Top10),
+		HomeSectionNewEps:      len(finalResponse.NewEps),
+		HomeSectionMovies:      len(finalResponse.Movies),
+		HomeSectionJadwalRilis: len(ongoingItemsForSchedule),
 	}
-	scheduleCollector.Wait()
-	log.Println("Scraping halaman 'Ongoing' selesai.")
+	finalResponse.Sections = sectionStatuses(pages, results, items)
 
 	finalResponse.JadwalRilis = s.generateJadwal(ongoingItemsForSchedule)
 	log.Println("Jadwal rilis dummy berhasil dibuat.")
 
+	s.updateCatalog(finalResponse, ongoingItemsForSchedule)
+
 	// Calculate confidence score based on data completeness
 	finalResponse.ConfidenceScore = s.calculateConfidenceScore(finalResponse)
 
@@ -98,6 +124,9 @@ func (s *HomeService) GetHomeData() (*models.FinalResponse, error) {
 	} else {
 		finalResponse.Message = "Data berhasil diambil dengan kelengkapan sempurna"
 	}
+	if len(errs) > 0 {
+		finalResponse.Message += " (sebagian section gagal diambil)"
+	}
 
 	return finalResponse, nil
 }
@@ -148,15 +177,18 @@ func (s *HomeService) parseTop10Item(e *colly.HTMLElement) models.Top10Item {
 func (s *HomeService) parseJadwalItem(e *colly.HTMLElement) models.JadwalItem {
 	url := e.ChildAttr("a", "href")
 	judul := scrape.CleanTitle(e.ChildText(".movie-title a"))
+	slug := scrape.GenerateSlug(url)
+	// Perkiraan yang sama dengan endpoint jadwal rilis
+	estimate := estimateSchedule(slug)
 	return models.JadwalItem{
 		Title:       judul,
 		URL:         url,
-		AnimeSlug:   scrape.GenerateSlug(url),
+		AnimeSlug:   slug,
 		CoverURL:    e.ChildAttr("img", "src"),
-		Type:        "TV",                                                   // Dummy
-		Score:       fmt.Sprintf("%.1f", 7.0+rand.Float64()),                // Dummy
-		Genres:      []string{"Drama", "Romance", "Comedy"},                 // Dummy
-		ReleaseTime: fmt.Sprintf("%02d:%02d", rand.Intn(24), rand.Intn(60)), // Dummy
+		Type:        ongoingType,
+		Score:       previewScore(e),
+		Genres:      []string{"Drama", "Romance", "Comedy"}, // Dummy
+		ReleaseTime: estimate.ReleaseTime,
 	}
 }
 
@@ -175,11 +207,8 @@ func (s *HomeService) generateJadwal(items []models.JadwalItem) models.JadwalRil
 		return jadwal
 	}
 
-	days := []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
-
 	for _, item := range items {
-		dayIndex := rand.Intn(len(days))
-		switch days[dayIndex] {
+		switch estimateSchedule(item.AnimeSlug).Day {
 		case "Monday":
 			jadwal.Monday = append(jadwal.Monday, item)
 		case "Tuesday":
@@ -199,6 +228,22 @@ func (s *HomeService) generateJadwal(items []models.JadwalItem) models.JadwalRil
 	return jadwal
 }
 
+// updateCatalog stores every scraped home page item in the catalog
+func (s *HomeService) updateCatalog(response *models.FinalResponse, scheduleItems []models.JadwalItem) {
+	for _, item := range response.Top10 {
+		s.catalog.UpsertDrama(catalog.Drama{Slug: item.AnimeSlug, Title: item.Judul, URL: item.URL, Cover: item.Cover})
+	}
+	for _, item := range response.NewEps {
+		s.catalog.UpsertDrama(catalog.Drama{Slug: item.AnimeSlug, Title: item.Judul, URL: item.URL, Cover: item.Cover})
+	}
+	for _, item := range response.Movies {
+		s.catalog.UpsertDrama(catalog.Drama{Slug: item.AnimeSlug, Title: item.Judul, URL: item.URL, Cover: item.Cover})
+	}
+	for _, item := range scheduleItems {
+		s.catalog.UpsertDrama(catalog.Drama{Slug: item.AnimeSlug, Title: item.Title, URL: item.URL, Cover: item.CoverURL})
+	}
+}
+
 // calculateConfidenceScore calculates confidence score based on data completeness
 func (s *HomeService) calculateConfidenceScore(response *models.FinalResponse) float64 {
 	totalItems := 0
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/url"
@@ -10,21 +11,25 @@ import (
 	"time"
 
 	"github.com/gocolly/colly/v2"
+	"github.com/nabilulilalbab/dramaqu/catalog"
 	"github.com/nabilulilalbab/dramaqu/models"
 )
 
 // MovieService handles movie data scraping
-type MovieService struct{}
+type MovieService struct {
+	catalog *catalog.Catalog
+	site    Site
+}
 
 // NewMovieService creates a new instance of MovieService
-func NewMovieService() *MovieService {
-	return &MovieService{}
+func NewMovieService(cat *catalog.Catalog, site Site) *MovieService {
+	return &MovieService{catalog: cat, site: site}
 }
 
 // GetMovies scrapes and returns movie data with the exact same logic as the test
-func (s *MovieService) GetMovies(page int) (*models.DramaListResponse, error) {
+func (s *MovieService) GetMovies(ctx context.Context, page int) (*models.DramaListResponse, error) {
 	// Build target URL based on page number
-	baseURL := "https://dramaqu.ad/drama-list/"
+	baseURL := s.site.URL("/drama-list/")
 	targetURL := baseURL
 	if page > 1 {
 		targetURL = fmt.Sprintf("%spage/%d/", baseURL, page)
@@ -39,10 +44,12 @@ func (s *MovieService) GetMovies(page int) (*models.DramaListResponse, error) {
 	}
 
 	c := colly.NewCollector(
-		colly.AllowedDomains("dramaqu.ad"),
+		colly.AllowedDomains(s.site.Domains()...),
+		colly.StdlibContext(ctx),
 		colly.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.0.0 Safari/537.36"),
 	)
 	c.SetRequestTimeout(30 * time.Second)
+	s.site.guard(c)
 
 	// Regex untuk membersihkan angka dari string views
 	reViews := regexp.MustCompile(`[0-9,]+`)
@@ -84,11 +91,14 @@ func (s *MovieService) GetMovies(page int) (*models.DramaListResponse, error) {
 	})
 
 	// Visit target page
-	err := c.Visit(targetURL)
-	if err != nil {
-		return nil, fmt.Errorf("gagal mengunjungi URL: %v", err)
+	if err := visit(ctx, c, targetURL); err != nil {
+		return nil, err
+	}
+
+	// Simpan hasil scraping ke catalog untuk saran pencarian
+	for _, item := range response.Data {
+		s.catalog.UpsertDrama(catalog.Drama{Slug: item.Slug, Title: item.Judul, URL: item.URL, Cover: item.Cover})
 	}
-	c.Wait()
 
 	// Calculate confidence score based on data completeness
 	response.ConfidenceScore = s.calculateConfidenceScore(response)
@@ -0,0 +1,257 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/nabilulilalbab/dramaqu/models"
+)
+
+// Nonce extraction strategies, in the order they are tried
+const (
+	NonceStrategyDataURIScript   = "data_uri_script"
+	NonceStrategyInlineScriptVar = "inline_script_var"
+	NonceStrategyWPLocalizeJSON  = "wp_localize_json"
+	NonceStrategyDataAttributes  = "data_attributes"
+)
+
+// Warning codes returned in EpisodeDetailResponse.Warnings
+const (
+	WarningPlayerIDNotFound = "PLAYER_ID_NOT_FOUND"
+	WarningNonceNotFound    = "NONCE_NOT_FOUND"
+)
+
+// playerScriptSelector is the script tag wp_localize_script emits for the player
+const playerScriptSelector = "script#dramagu-player-js-extra"
+
+var (
+	// reNonceValue matches "nonce":"abc123" style keys in decoded scripts
+	reNonceValue = regexp.MustCompile(`["']?\bnonce\b["']?\s*:\s*["']([\w-]+)["']`)
+	// reInlineNonce matches a nonce inside a JS variable declaration or assignment,
+	// e.g. var dramagu_player = {..., nonce: 'abc'} atau player.nonce = "abc"
+	reInlineNonce = regexp.MustCompile(`(?:(?:var|let|const)\s+[\w$]+\s*=\s*\{[^;]*?["']?\bnonce\b["']?\s*:|\.nonce\s*=)\s*["']([\w-]+)["']`)
+)
+
+// nonceStrategy is one way of finding the AJAX nonce on an episode page.
+// extract mengembalikan nonce, atau alasan kegagalan jika nonce kosong.
+type nonceStrategy struct {
+	name    string
+	extract func(doc *goquery.Selection) (nonce string, reason string)
+}
+
+// nonceStrategies lists the strategies in the order they are tried
+var nonceStrategies = []nonceStrategy{
+	{NonceStrategyDataURIScript, nonceFromDataURIScript},
+	{NonceStrategyInlineScriptVar, nonceFromInlineScriptVar},
+	{NonceStrategyWPLocalizeJSON, nonceFromWPLocalizeJSON},
+	{NonceStrategyDataAttributes, nonceFromDataAttributes},
+}
+
+// extractNonce tries every nonce strategy in order and returns the first nonce found
+// with the strategy name. Jika semua gagal, setiap alasan dikembalikan sebagai warning.
+func (s *EpisodeDetailService) extractNonce(doc *goquery.Selection) (string, string, []models.ExtractionWarning) {
+	var warnings []models.ExtractionWarning
+	for _, strategy := range nonceStrategies {
+		nonce, reason := strategy.extract(doc)
+		if nonce != "" {
+			return nonce, strategy.name, nil
+		}
+		warnings = append(warnings, models.ExtractionWarning{
+			Code:     WarningNonceNotFound,
+			Strategy: strategy.name,
+			Message:  reason,
+		})
+	}
+	return "", "", warnings
+}
+
+// nonceFromDataURIScript decodes the base64 data URI of the player script
+func nonceFromDataURIScript(doc *goquery.Selection) (string, string) {
+	dataURI, exists := doc.Find(playerScriptSelector).Attr("src")
+	if !exists {
+		return "", fmt.Sprintf("script tag '%s' dengan atribut src tidak ditemukan", playerScriptSelector)
+	}
+
+	header, payload, ok := strings.Cut(dataURI, ",")
+	if !ok || !strings.HasPrefix(header, "data:") {
+		return "", "format data URI pada script tidak valid"
+	}
+
+	var decoded string
+	if strings.HasSuffix(header, ";base64") {
+		raw, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			return "", fmt.Sprintf("gagal men-decode base64: %v", err)
+		}
+		decoded = string(raw)
+	} else {
+		unescaped, err := url.PathUnescape(payload)
+		if err != nil {
+			return "", fmt.Sprintf("gagal men-decode data URI: %v", err)
+		}
+		decoded = unescaped
+	}
+
+	if nonce := firstMatch(reNonceValue, decoded); nonce != "" {
+		return nonce, ""
+	}
+	return "", "nonce tidak ditemukan di script yang di-decode"
+}
+
+// nonceFromInlineScriptVar searches inline scripts for a nonce in a JS variable
+func nonceFromInlineScriptVar(doc *goquery.Selection) (string, string) {
+	scripts := doc.Find("script:not([src])")
+	if scripts.Length() == 0 {
+		return "", "tidak ada inline script di halaman"
+	}
+
+	var nonce string
+	scripts.EachWithBreak(func(_ int, sel *goquery.Selection) bool {
+		nonce = firstMatch(reInlineNonce, sel.Text())
+		return nonce == ""
+	})
+	if nonce == "" {
+		return "", "tidak ada variabel JavaScript berisi nonce di inline script"
+	}
+	return nonce, ""
+}
+
+// nonceFromWPLocalizeJSON parses the JSON objects wp_localize_script prints
+// (var name = {...};) and looks for nonce keys at any depth, e.g. "ajax_nonce".
+func nonceFromWPLocalizeJSON(doc *goquery.Selection) (string, string) {
+	var nonce string
+	found := false
+	doc.Find("script:not([src])").EachWithBreak(func(_ int, sel *goquery.Selection) bool {
+		for _, object := range jsonObjects(sel.Text()) {
+			found = true
+			var data map[string]interface{}
+			if err := json.Unmarshal([]byte(object), &data); err != nil {
+				continue
+			}
+			if nonce = findNonceKey(data); nonce != "" {
+				return false
+			}
+		}
+		return true
+	})
+
+	switch {
+	case nonce != "":
+		return nonce, ""
+	case !found:
+		return "", "tidak ada objek JSON wp_localize_script di inline script"
+	default:
+		return "", "objek JSON wp_localize_script tidak memiliki key nonce"
+	}
+}
+
+// nonceFromDataAttributes reads the nonce from data-* attributes on player elements
+func nonceFromDataAttributes(doc *goquery.Selection) (string, string) {
+	for _, attr := range []string{"data-nonce", "data-player-nonce", "data-ajax-nonce", "data-security"} {
+		if value := strings.TrimSpace(doc.Find("["+attr+"]").First().AttrOr(attr, "")); value != "" {
+			return value, ""
+		}
+	}
+	return "", "tidak ada elemen dengan atribut data-nonce"
+}
+
+// findNonceKey returns the string value of the key "nonce", otherwise of the first key
+// ending with "_nonce". Key diurutkan agar hasilnya sama di setiap run meskipun ada
+// beberapa key yang cocok; objek bersarang diperiksa setelah key di level ini.
+func findNonceKey(data map[string]interface{}) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	suffixNonce := ""
+	for _, key := range keys {
+		str, ok := data[key].(string)
+		if !ok || str == "" {
+			continue
+		}
+		switch lower := strings.ToLower(key); {
+		case lower == "nonce":
+			return str
+		case suffixNonce == "" && strings.HasSuffix(lower, "_nonce"):
+			suffixNonce = str
+		}
+	}
+	if suffixNonce != "" {
+		return suffixNonce
+	}
+	for _, key := range keys {
+		if nested, ok := data[key].(map[string]interface{}); ok {
+			if nonce := findNonceKey(nested); nonce != "" {
+				return nonce
+			}
+		}
+	}
+	return ""
+}
+
+// jsonObjects returns every balanced {...} object assigned in a script (after "=")
+func jsonObjects(script string) []string {
+	var objects []string
+	for i := 0; i < len(script); i++ {
+		if script[i] != '=' {
+			continue
+		}
+		start := i + 1
+		for start < len(script) && (script[start] == ' ' || script[start] == '\t' || script[start] == '\n') {
+			start++
+		}
+		if start >= len(script) || script[start] != '{' {
+			continue
+		}
+		if end := matchBrace(script, start); end > start {
+			objects = append(objects, script[start:end+1])
+			i = end
+		}
+	}
+	return objects
+}
+
+// matchBrace returns the index of the brace closing the one at start, skipping string literals
+func matchBrace(s string, start int) int {
+	depth := 0
+	var quote byte
+	for i := start; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '\'':
+			quote = c
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// firstMatch returns the first capture group of re in s
+func firstMatch(re *regexp.Regexp, s string) string {
+	if m := re.FindStringSubmatch(s); len(m) > 1 {
+		return m[1]
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"testing"
+)
+
+func TestExtractNonceStrategies(t *testing.T) {
+	cases := []struct {
+		fixture      string
+		wantNonce    string
+		wantStrategy string
+	}{
+		{"player_nonce_data_uri.html", "a1b2c3d4e5", NonceStrategyDataURIScript},
+		{"player_nonce_inline_var.html", "f6e5d4c3b2", NonceStrategyInlineScriptVar},
+		{"player_nonce_wp_localize.html", "9z8y7x6w5v", NonceStrategyWPLocalizeJSON},
+		{"player_nonce_data_attr.html", "0k9j8h7g6f", NonceStrategyDataAttributes},
+	}
+
+	s := &EpisodeDetailService{}
+	for _, tc := range cases {
+		t.Run(tc.fixture, func(t *testing.T) {
+			nonce, strategy, warnings := s.extractNonce(mustParseDoc(t, loadFixture(t, tc.fixture)))
+			if nonce != tc.wantNonce || strategy != tc.wantStrategy {
+				t.Errorf("extractNonce = (%q, %q), want (%q, %q)", nonce, strategy, tc.wantNonce, tc.wantStrategy)
+			}
+			if len(warnings) != 0 {
+				t.Errorf("warnings = %+v, want none on success", warnings)
+			}
+		})
+	}
+}
+
+func TestExtractNonceReportsEveryFailedStrategy(t *testing.T) {
+	s := &EpisodeDetailService{}
+	nonce, strategy, warnings := s.extractNonce(mustParseDoc(t, loadFixture(t, "player_nonce_none.html")))
+
+	if nonce != "" || strategy != "" {
+		t.Fatalf("extractNonce = (%q, %q), want nothing", nonce, strategy)
+	}
+	if len(warnings) != len(nonceStrategies) {
+		t.Fatalf("warnings = %+v, want one per strategy", warnings)
+	}
+	for i, w := range warnings {
+		if w.Code != WarningNonceNotFound || w.Strategy != nonceStrategies[i].name || w.Message == "" {
+			t.Errorf("warning %d = %+v", i, w)
+		}
+	}
+}
+
+func TestFindNonceKeyIsDeterministic(t *testing.T) {
+	cases := []struct {
+		data map[string]interface{}
+		want string
+	}{
+		// "nonce" lebih diutamakan daripada key berakhiran _nonce
+		{map[string]interface{}{"ajax_nonce": "aaa", "nonce": "bbb", "z_nonce": "ccc"}, "bbb"},
+		// Beberapa key _nonce: urutan alfabet
+		{map[string]interface{}{"z_nonce": "ccc", "player_nonce": "ddd", "ajax_nonce": "aaa"}, "aaa"},
+		// Key di level ini lebih diutamakan daripada objek bersarang
+		{map[string]interface{}{"a": map[string]interface{}{"nonce": "nested"}, "x_nonce": "top"}, "top"},
+		{map[string]interface{}{"b": map[string]interface{}{"nonce": "bbb"}, "a": map[string]interface{}{"Nonce": "aaa"}}, "aaa"},
+		{map[string]interface{}{"nonce": "", "url": "https://dramaqu.ad/"}, ""},
+	}
+	for _, tc := range cases {
+		// Iterasi map Go acak, jadi ulangi beberapa kali
+		for i := 0; i < 20; i++ {
+			if got := findNonceKey(tc.data); got != tc.want {
+				t.Fatalf("findNonceKey(%v) = %q, want %q", tc.data, got, tc.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/nabilulilalbab/dramaqu/catalog"
+)
+
+// siteLocation is the timezone used by dramaqu.ad (WIB)
+var siteLocation = loadSiteLocation()
+
+// reEpisodeNumber extracts the episode number from link text such as "12" or "Episode 12"
+var reEpisodeNumber = regexp.MustCompile(`\d+`)
+
+// postDateLayouts lists the date formats found in WordPress metadata
+var postDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// loadSiteLocation loads Asia/Jakarta, falling back to a fixed UTC+7 zone
+func loadSiteLocation() *time.Location {
+	if loc, err := time.LoadLocation("Asia/Jakarta"); err == nil {
+		return loc
+	}
+	return time.FixedZone("WIB", 7*60*60)
+}
+
+// postDates holds the publish and modify dates of a WordPress post
+type postDates struct {
+	Published time.Time
+	Modified  time.Time
+}
+
+// extractPostDates reads the post dates from page metadata.
+// Urutan sumber: meta article:*, JSON-LD, lalu elemen <time> bawaan tema WordPress.
+func extractPostDates(doc *goquery.Selection) postDates {
+	var dates postDates
+
+	dates.Published = parsePostDate(doc.Find("meta[property='article:published_time']").AttrOr("content", ""))
+	dates.Modified = parsePostDate(doc.Find("meta[property='article:modified_time']").AttrOr("content", ""))
+
+	if dates.Published.IsZero() || dates.Modified.IsZero() {
+		doc.Find("script[type='application/ld+json']").Each(func(_ int, s *goquery.Selection) {
+			var data interface{}
+			if err := json.Unmarshal([]byte(s.Text()), &data); err != nil {
+				return
+			}
+			if dates.Published.IsZero() {
+				dates.Published = parsePostDate(findJSONLDString(data, "datePublished"))
+			}
+			if dates.Modified.IsZero() {
+				dates.Modified = parsePostDate(findJSONLDString(data, "dateModified"))
+			}
+		})
+	}
+
+	if dates.Published.IsZero() {
+		for _, selector := range []string{"time.entry-date", "time.published", "time[itemprop='datePublished']"} {
+			if t := parsePostDate(doc.Find(selector).First().AttrOr("datetime", "")); !t.IsZero() {
+				dates.Published = t
+				break
+			}
+		}
+		if dates.Published.IsZero() {
+			dates.Published = parsePostDate(doc.Find("meta[itemprop='datePublished']").AttrOr("content", ""))
+		}
+	}
+	if dates.Modified.IsZero() {
+		dates.Modified = parsePostDate(doc.Find("time.updated").First().AttrOr("datetime", ""))
+	}
+
+	return dates
+}
+
+// findJSONLDString searches a decoded JSON-LD document (including @graph) for a string key
+func findJSONLDString(data interface{}, key string) string {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		if value, ok := v[key].(string); ok {
+			return value
+		}
+		for _, child := range v {
+			if value := findJSONLDString(child, key); value != "" {
+				return value
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if value := findJSONLDString(child, key); value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// parsePostDate parses a metadata date; dates without offset are in site time
+func parsePostDate(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+	for _, layout := range postDateLayouts {
+		if t, err := time.ParseInLocation(layout, value, siteLocation); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// parseEpisodeNumber returns the episode number in text, or 0 if there is none
+func parseEpisodeNumber(text string) int {
+	num, err := strconv.Atoi(reEpisodeNumber.FindString(text))
+	if err != nil {
+		return 0
+	}
+	return num
+}
+
+// episodeReleaseDate returns the ISO-8601 release date of one episode of a multi-page post.
+// Semua episode berada dalam satu post WordPress, sehingga tanggal publish post hanya
+// berlaku untuk episode 1 dan tanggal modifikasi untuk episode terbaru. Episode lain
+// memakai tanggal yang pernah tercatat atau waktu pertama kali terlihat di catalog.
+func episodeReleaseDate(cat *catalog.Catalog, episodeURL string, episodeNum, latestNum int, dates postDates) string {
+	var publishedAt time.Time
+	switch {
+	case episodeNum == 1:
+		publishedAt = dates.Published
+	case episodeNum > 1 && episodeNum == latestNum:
+		publishedAt = dates.Modified
+	}
+
+	if episodeURL == "" {
+		if publishedAt.IsZero() {
+			return "Unknown"
+		}
+		return publishedAt.In(siteLocation).Format(time.RFC3339)
+	}
+
+	// Tanggal yang sudah tercatat tidak ditimpa oleh tanggal modifikasi yang lebih baru
+	if existing, ok := cat.Episode(episodeURL); ok && !existing.PublishedAt.IsZero() && episodeNum != 1 {
+		publishedAt = time.Time{}
+	}
+	episode := cat.RecordEpisode(episodeURL, publishedAt)
+	return episode.ReleasedAt().In(siteLocation).Format(time.RFC3339)
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/nabilulilalbab/dramaqu/catalog"
+)
+
+func mustParseDoc(t *testing.T, html string) *goquery.Selection {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("failed to parse html: %v", err)
+	}
+	return doc.Selection
+}
+
+func TestExtractPostDatesSources(t *testing.T) {
+	tests := []struct {
+		name          string
+		html          string
+		wantPublished string
+		wantModified  string
+	}{
+		{
+			name: "open graph meta",
+			html: `<html><head>
+				<meta property="article:published_time" content="2025-08-01T20:00:00+07:00">
+				<meta property="article:modified_time" content="2025-08-22T21:30:00+07:00">
+			</head><body></body></html>`,
+			wantPublished: "2025-08-01T20:00:00+07:00",
+			wantModified:  "2025-08-22T21:30:00+07:00",
+		},
+		{
+			name: "json-ld graph",
+			html: `<html><head><script type="application/ld+json">
+				{"@context":"https://schema.org","@graph":[{"@type":"WebPage"},{"@type":"Article","datePublished":"2025-07-10T10:00:00+00:00","dateModified":"2025-07-20T10:00:00+00:00"}]}
+			</script></head><body></body></html>`,
+			wantPublished: "2025-07-10T17:00:00+07:00",
+			wantModified:  "2025-07-20T17:00:00+07:00",
+		},
+		{
+			name:          "wordpress time element without offset",
+			html:          `<html><body><time class="entry-date published" datetime="2025-06-05 19:45:00">5 Juni</time></body></html>`,
+			wantPublished: "2025-06-05T19:45:00+07:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dates := extractPostDates(mustParseDoc(t, tt.html))
+			if got := formatOrEmpty(dates.Published); got != tt.wantPublished {
+				t.Errorf("published = %q, want %q", got, tt.wantPublished)
+			}
+			if got := formatOrEmpty(dates.Modified); got != tt.wantModified {
+				t.Errorf("modified = %q, want %q", got, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestEpisodeReleaseDate(t *testing.T) {
+	cat := catalog.New()
+	dates := postDates{
+		Published: time.Date(2025, 8, 1, 20, 0, 0, 0, siteLocation),
+		Modified:  time.Date(2025, 8, 22, 21, 0, 0, 0, siteLocation),
+	}
+
+	if got := episodeReleaseDate(cat, "https://dramaqu.ad/drama/", 1, 4, dates); got != "2025-08-01T20:00:00+07:00" {
+		t.Errorf("episode 1 = %q, want post publish date", got)
+	}
+	if got := episodeReleaseDate(cat, "https://dramaqu.ad/drama/4/", 4, 4, dates); got != "2025-08-22T21:00:00+07:00" {
+		t.Errorf("latest episode = %q, want post modified date", got)
+	}
+
+	// Setelah episode 5 rilis, episode 4 tetap memakai tanggal yang sudah tercatat
+	later := dates
+	later.Modified = time.Date(2025, 8, 29, 21, 0, 0, 0, siteLocation)
+	if got := episodeReleaseDate(cat, "https://dramaqu.ad/drama/4", 4, 5, later); got != "2025-08-22T21:00:00+07:00" {
+		t.Errorf("recorded episode = %q, want previously recorded date", got)
+	}
+
+	middle := episodeReleaseDate(cat, "https://dramaqu.ad/drama/2/", 2, 5, later)
+	if _, err := time.Parse(time.RFC3339, middle); err != nil {
+		t.Errorf("episode without metadata should fall back to first-seen time, got %q", middle)
+	}
+}
+
+func formatOrEmpty(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(siteLocation).Format(time.RFC3339)
+}
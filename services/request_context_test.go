@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nabilulilalbab/dramaqu/catalog"
+)
+
+func TestScrapeStopsWhenContextIsDone(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	cat := catalog.New()
+	scrapes := map[string]func(ctx context.Context) error{
+		"anime terbaru": func(ctx context.Context) error {
+			_, err := NewAnimeTerbaruService(cat, testSite).GetAnimeTerbaru(ctx, 1)
+			return err
+		},
+		"episode detail (async)": func(ctx context.Context) error {
+			_, err := NewEpisodeDetailService(cat, testSite).GetEpisodeBySlug(ctx, "love-in-the-air", 2)
+			return err
+		},
+		"genres": func(ctx context.Context) error {
+			_, err := NewGenreService(cat, testSite).GetGenres(ctx)
+			return err
+		},
+	}
+
+	for name, scrape := range scrapes {
+		if err := scrape(cancelled); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s with cancelled context: err = %v, want context.Canceled", name, err)
+		}
+		if err := scrape(expired); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("%s past its deadline: err = %v, want context.DeadlineExceeded", name, err)
+		}
+	}
+}
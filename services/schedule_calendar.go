@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nabilulilalbab/dramaqu/ical"
+)
+
+// siteTZID is the IANA name of siteLocation, dipakai di VTIMEZONE
+const siteTZID = "Asia/Jakarta"
+
+// episodeDuration is the assumed length of one episode in the calendar
+const episodeDuration = time.Hour
+
+// scheduleDays lists the schedule day keys in calendar order
+var scheduleDays = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+// calendarEntry is the part of a schedule entry needed for a calendar event
+type calendarEntry struct {
+	Day         string
+	Title       string
+	URL         string
+	Slug        string
+	CoverURL    string
+	ReleaseTime string
+}
+
+// Calendar returns the release schedule as weekly recurring events in Asia/Jakarta,
+// satu event per drama. day kosong berarti semua hari; slugs (opsional) membatasi
+// drama yang diekspor.
+func (s *ScheduleService) Calendar(ctx context.Context, day string, slugs []string) (*ical.Calendar, error) {
+	var entries []calendarEntry
+	if day == "" {
+		schedule, err := s.GetReleaseSchedule(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, scheduleDay := range scheduleDays {
+			for _, item := range schedule.Data[scheduleDay] {
+				entries = append(entries, calendarEntry{scheduleDay, item.Title, item.URL, item.Slug, item.CoverURL, item.ReleaseTime})
+			}
+		}
+	} else {
+		schedule, err := s.GetScheduleByDay(ctx, day, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range schedule.Data {
+			entries = append(entries, calendarEntry{day, item.Title, item.URL, item.Slug, item.CoverURL, item.ReleaseTime})
+		}
+	}
+
+	// DTSTART dan DTSTAMP memakai minggu saat jadwal diambil, sehingga dokumen
+	// (dan ETag-nya) tidak berubah selama jadwalnya sama di minggu yang sama
+	weekStart := startOfWeek(time.Now().In(siteLocation))
+	name := "Jadwal Rilis DramaQu"
+	if day != "" {
+		name += " - " + day
+	}
+	cal := &ical.Calendar{
+		ProdID:      "-//DramaQu//Jadwal Rilis//ID",
+		Name:        name,
+		Description: "Jadwal rilis drama ongoing dari dramaqu.ad",
+		TZID:        siteTZID,
+		Location:    siteLocation,
+		Stamp:       weekStart,
+	}
+
+	followed := make(map[string]bool, len(slugs))
+	for _, slug := range slugs {
+		followed[slug] = true
+	}
+	for _, entry := range entries {
+		if entry.Slug == "" || (len(followed) > 0 && !followed[entry.Slug]) {
+			continue
+		}
+		start, ok := weeklyStart(weekStart, entry.Day, entry.ReleaseTime)
+		if !ok {
+			continue
+		}
+		cal.Events = append(cal.Events, ical.Event{
+			UID:         "jadwal-" + entry.Slug + "@dramaqu.ad",
+			Summary:     strings.TrimSpace(entry.Title),
+			Description: "Nonton: " + entry.URL + "\nCover: " + entry.CoverURL,
+			URL:         entry.URL,
+			Image:       entry.CoverURL,
+			Start:       start,
+			Duration:    episodeDuration,
+		})
+	}
+	sort.SliceStable(cal.Events, func(i, j int) bool {
+		return cal.Events[i].Start.Before(cal.Events[j].Start)
+	})
+	return cal, nil
+}
+
+// startOfWeek returns Monday 00:00 of the week containing t, in t's location
+func startOfWeek(t time.Time) time.Time {
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, t.Location())
+}
+
+// weeklyStart returns the occurrence of day and "HH:MM" releaseTime in the week starting at weekStart
+func weeklyStart(weekStart time.Time, day, releaseTime string) (time.Time, bool) {
+	clock, err := time.Parse("15:04", strings.TrimSpace(releaseTime))
+	if err != nil {
+		return time.Time{}, false
+	}
+	for i, scheduleDay := range scheduleDays {
+		if strings.EqualFold(scheduleDay, day) {
+			return time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day()+i, clock.Hour(), clock.Minute(), 0, 0, weekStart.Location()), true
+		}
+	}
+	return time.Time{}, false
+}
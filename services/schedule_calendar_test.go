@@ -0,0 +1,79 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nabilulilalbab/dramaqu/catalog"
+	"github.com/nabilulilalbab/dramaqu/ical"
+)
+
+func TestWeeklyStart(t *testing.T) {
+	// Minggu malam masih termasuk minggu yang dimulai Senin sebelumnya
+	sunday := time.Date(2026, 10, 25, 23, 0, 0, 0, siteLocation)
+	weekStart := startOfWeek(sunday)
+	if want := time.Date(2026, 10, 19, 0, 0, 0, 0, siteLocation); !weekStart.Equal(want) {
+		t.Fatalf("startOfWeek(%v) = %v, want %v", sunday, weekStart, want)
+	}
+
+	tests := []struct {
+		day, releaseTime string
+		want             time.Time
+		ok               bool
+	}{
+		{"Monday", "20:30", time.Date(2026, 10, 19, 20, 30, 0, 0, siteLocation), true},
+		{"sunday", "07:05", time.Date(2026, 10, 25, 7, 5, 0, 0, siteLocation), true},
+		{"Funday", "20:30", time.Time{}, false},
+		{"Monday", "Unknown", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := weeklyStart(weekStart, tt.day, tt.releaseTime)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("weeklyStart(%q, %q) = %v, %v, want %v, %v", tt.day, tt.releaseTime, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCalendarIsStable(t *testing.T) {
+	srv := newScheduleTestServer(t, 20)
+	s := NewScheduleService(catalog.New(), localSite(srv.URL))
+
+	all, err := s.Calendar(context.Background(), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := s.Calendar(context.Background(), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Dokumen yang sama menghasilkan ETag yang sama
+	if !bytes.Equal(ical.Encode(all), ical.Encode(again)) {
+		t.Error("calendar changed between requests")
+	}
+	if want := startOfWeek(time.Now().In(siteLocation)); !all.Stamp.Equal(want) {
+		t.Errorf("Stamp = %v, want start of week %v", all.Stamp, want)
+	}
+
+	starts := make(map[string]time.Time, len(all.Events))
+	for _, event := range all.Events {
+		starts[event.UID] = event.Start
+	}
+	total := 0
+	for _, day := range scheduleDays {
+		byDay, err := s.Calendar(context.Background(), day, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, event := range byDay.Events {
+			total++
+			if want, ok := starts[event.UID]; !ok || !event.Start.Equal(want) {
+				t.Errorf("%s %s: start %v, all-days export has %v", day, event.UID, event.Start, want)
+			}
+		}
+	}
+	if total != len(all.Events) || total != 20 {
+		t.Errorf("per-day exports have %d events, all-days export %d, want 20", total, len(all.Events))
+	}
+}
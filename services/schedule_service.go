@@ -1,29 +1,35 @@
 package services
 
 import (
+	"context"
 	"fmt"
+	"hash/fnv"
 	"log"
-	"math/rand"
 	"net/url"
 	"path"
 	"strings"
 	"time"
 
 	"github.com/gocolly/colly/v2"
+	"github.com/nabilulilalbab/dramaqu/catalog"
 	"github.com/nabilulilalbab/dramaqu/models"
 )
 
 // ScheduleService handles schedule data scraping
-type ScheduleService struct{}
+type ScheduleService struct {
+	catalog *catalog.Catalog
+	site    Site
+}
 
 // NewScheduleService creates a new instance of ScheduleService
-func NewScheduleService() *ScheduleService {
-	return &ScheduleService{}
+func NewScheduleService(cat *catalog.Catalog, site Site) *ScheduleService {
+	return &ScheduleService{catalog: cat, site: site}
 }
 
-// GetReleaseSchedule scrapes and returns release schedule data with the exact same logic as the test
-func (s *ScheduleService) GetReleaseSchedule() (*models.ReleaseScheduleResponse, error) {
-	targetURL := "https://dramaqu.ad/category/ongoing-drama/"
+// GetReleaseSchedule scrapes and returns release schedule data with the exact same logic as the test.
+// Hari dan jam rilis dikonversi ke loc (nil berarti WIB, zona waktu situs).
+func (s *ScheduleService) GetReleaseSchedule(ctx context.Context, loc *time.Location) (*models.ReleaseScheduleResponse, error) {
+	targetURL := s.site.URL(ongoingPath)
 
 	// Map untuk menampung data yang dikelompokkan berdasarkan hari
 	scheduleData := make(map[string][]models.ReleaseEntry)
@@ -33,12 +39,13 @@ func (s *ScheduleService) GetReleaseSchedule() (*models.ReleaseScheduleResponse,
 	}
 
 	c := colly.NewCollector(
-		colly.AllowedDomains("dramaqu.ad"),
+		colly.AllowedDomains(s.site.Domains()...),
+		colly.StdlibContext(ctx),
 		colly.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.0.0 Safari/537.36"),
 	)
 	c.SetRequestTimeout(30 * time.Second)
+	s.site.guard(c)
 
-	// Counter untuk mendistribusikan drama ke hari yang berbeda
 	itemCounter := 0
 
 	c.OnHTML("article.movie-preview", func(e *colly.HTMLElement) {
@@ -51,37 +58,21 @@ func (s *ScheduleService) GetReleaseSchedule() (*models.ReleaseScheduleResponse,
 			slug = path.Base(strings.TrimSuffix(parsedURL.Path, "/"))
 		}
 
-		// --- Membuat Data Gimmick ---
-		rand.Seed(time.Now().UnixNano() + int64(itemCounter)) // Seed randomizer
-
-		// Waktu rilis acak
-		releaseHour := rand.Intn(24)
-		releaseMinute := rand.Intn(60)
-		releaseTime := fmt.Sprintf("%02d:%02d", releaseHour, releaseMinute)
-
-		// Skor acak antara 7.0 dan 9.5
-		score := 7.0 + rand.Float64()*(9.5-7.0)
-
-		// Tipe acak (lebih sering TV)
-		dramaType := "TV"
-		if rand.Intn(10) > 8 { // 20% kemungkinan menjadi Movie
-			dramaType = "Movie"
-		}
+		// Hari dan jam rilis diperkirakan dari slug, sama dengan GetScheduleByDay
+		estimate := estimateSchedule(slug)
 
 		entry := models.ReleaseEntry{
 			Title:       titleElement.Text(),
 			URL:         dramaURL,
 			Slug:        slug,
 			CoverURL:    e.DOM.Find("img.keremiya-image").AttrOr("src", ""),
-			Type:        dramaType,
-			Score:       fmt.Sprintf("%.1f", score),
+			Type:        ongoingType,
+			Score:       previewScore(e),
 			Genres:      []string{"Drama", "Romance", "Comedy"}, // Genre gimmick
-			ReleaseTime: releaseTime,
+			ReleaseTime: estimate.ReleaseTime,
 		}
 
-		// Distribusikan ke dalam map jadwal secara bergiliran
-		dayKey := days[itemCounter%len(days)]
-		scheduleData[dayKey] = append(scheduleData[dayKey], entry)
+		scheduleData[estimate.Day] = append(scheduleData[estimate.Day], entry)
 		itemCounter++
 	})
 
@@ -93,22 +84,30 @@ func (s *ScheduleService) GetReleaseSchedule() (*models.ReleaseScheduleResponse,
 		log.Printf("Error saat request ke %s: %v", r.Request.URL, err)
 	})
 
-	err := c.Visit(targetURL)
-	if err != nil {
-		return nil, fmt.Errorf("gagal mengunjungi URL: %v", err)
+	if err := visit(ctx, c, targetURL); err != nil {
+		return nil, err
 	}
-	c.Wait()
 
 	if itemCounter == 0 {
-		return nil, fmt.Errorf("tidak ada data drama yang berhasil di-scrape")
+		return nil, fmt.Errorf("%w: tidak ada data drama yang berhasil di-scrape", ErrUpstreamChanged)
+	}
+
+	// Simpan hasil scraping ke catalog untuk saran pencarian
+	for _, entries := range scheduleData {
+		for _, item := range entries {
+			s.catalog.UpsertDrama(catalog.Drama{Slug: item.Slug, Title: item.Title, URL: item.URL, Cover: item.CoverURL})
+		}
 	}
 
 	// Buat respons akhir
+	loc = scheduleLocation(loc)
 	response := &models.ReleaseScheduleResponse{
 		ConfidenceScore: 0.0, // Will be calculated
 		Message:         "Data berhasil diambil",
 		Source:          "dramaqu.ad",
-		Data:            scheduleData,
+		Timezone:        loc.String(),
+		Estimated:       true,
+		Data:            convertSchedule(scheduleData, time.Now(), loc),
 	}
 
 	// Calculate confidence score based on data completeness
@@ -128,64 +127,62 @@ func (s *ScheduleService) GetReleaseSchedule() (*models.ReleaseScheduleResponse,
 	return response, nil
 }
 
-// GetScheduleByDay scrapes and returns schedule data for specific day with the exact same logic as the test
-func (s *ScheduleService) GetScheduleByDay(inputDay string) (*models.ScheduleByDayResponse, error) {
-	targetURL := "https://dramaqu.ad/category/ongoing-drama/"
+// GetScheduleByDay scrapes and returns schedule data for specific day with the exact same logic as the test.
+// Hari dan jam rilis mengikuti loc (nil berarti WIB, zona waktu situs).
+func (s *ScheduleService) GetScheduleByDay(ctx context.Context, inputDay string, loc *time.Location) (*models.ScheduleByDayResponse, error) {
+	targetURL := s.site.URL(ongoingPath)
 
 	response := &models.ScheduleByDayResponse{
 		ConfidenceScore: 1.0,
 		Message:         "Data berhasil diambil",
 		Source:          "dramaqu.ad",
+		Timezone:        scheduleLocation(loc).String(),
+		Estimated:       true,
 		Data:            []models.ScheduleEntry{},
 	}
 
 	c := colly.NewCollector(
-		colly.AllowedDomains("dramaqu.ad"),
+		colly.AllowedDomains(s.site.Domains()...),
+		colly.StdlibContext(ctx),
 		colly.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.0.0 Safari/537.36"),
 	)
 	c.SetRequestTimeout(30 * time.Second)
+	s.site.guard(c)
 
-	itemCounter := 0
+	loc = scheduleLocation(loc)
+	now := time.Now()
 
 	c.OnHTML("article.movie-preview", func(e *colly.HTMLElement) {
 		title := e.DOM.Find("span.movie-title a").Text()
+		dramaURL := e.DOM.Find("span.movie-title a").AttrOr("href", "")
+		var slug string
+		if parsedURL, err := url.Parse(dramaURL); err == nil {
+			slug = path.Base(strings.TrimSuffix(parsedURL.Path, "/"))
+		}
 
-		// Tentukan hari rilis drama ini secara konsisten
-		releaseDay := s.getDayForTitle(title)
-
-		// HANYA proses item jika harinya cocok dengan input (case-insensitive)
-		if strings.EqualFold(releaseDay, inputDay) {
-
-			dramaURL := e.DOM.Find("span.movie-title a").AttrOr("href", "")
-			var slug string
-			if parsedURL, err := url.Parse(dramaURL); err == nil {
-				slug = path.Base(strings.TrimSuffix(parsedURL.Path, "/"))
-			}
-
-			// Buat data gimmick
-			rand.Seed(time.Now().UnixNano() + int64(itemCounter))
-			releaseHour := rand.Intn(24)
-			releaseMinute := rand.Intn(60)
-			score := 7.0 + rand.Float64()*(9.5-7.0)
-			dramaType := "TV"
-			if rand.Intn(10) > 8 {
-				dramaType = "Movie"
-			}
+		// Hari dan jam rilis (WIB) sama dengan GetReleaseSchedule; hari di zona waktu
+		// lain bergantung pada jamnya
+		estimate := estimateSchedule(slug)
+		air, ok := nextAiring(estimate.Day, estimate.ReleaseTime, now, loc)
 
-			entry := models.ScheduleEntry{
-				Title:       title,
-				URL:         dramaURL,
-				Slug:        slug,
-				CoverURL:    e.DOM.Find("img.keremiya-image").AttrOr("src", ""),
-				Type:        dramaType,
-				Score:       fmt.Sprintf("%.1f", score),
-				Genres:      []string{"Drama", "Romance", "Action"},
-				ReleaseTime: fmt.Sprintf("%02d:%02d", releaseHour, releaseMinute),
-			}
+		// HANYA proses item jika harinya (di zona waktu yang diminta) cocok dengan input
+		if !ok || !strings.EqualFold(air.Day, inputDay) {
+			return
+		}
 
-			response.Data = append(response.Data, entry)
-			itemCounter++
+		entry := models.ScheduleEntry{
+			Title:       title,
+			URL:         dramaURL,
+			Slug:        slug,
+			CoverURL:    e.DOM.Find("img.keremiya-image").AttrOr("src", ""),
+			Type:        ongoingType,
+			Score:       previewScore(e),
+			Genres:      []string{"Drama", "Romance", "Action"},
+			ReleaseTime: air.ReleaseTime,
+			NextAir:     air.NextAir,
 		}
+
+		response.Data = append(response.Data, entry)
 	})
 
 	c.OnRequest(func(r *colly.Request) {
@@ -196,14 +193,17 @@ func (s *ScheduleService) GetScheduleByDay(inputDay string) (*models.ScheduleByD
 		log.Printf("Error saat request ke %s: %v", r.Request.URL, err)
 	})
 
-	err := c.Visit(targetURL)
-	if err != nil {
-		return nil, fmt.Errorf("gagal mengunjungi URL: %v", err)
+	if err := visit(ctx, c, targetURL); err != nil {
+		return nil, err
 	}
-	c.Wait()
 
 	log.Printf("Menemukan %d item untuk hari %s.", len(response.Data), inputDay)
 
+	// Simpan hasil scraping ke catalog untuk saran pencarian
+	for _, item := range response.Data {
+		s.catalog.UpsertDrama(catalog.Drama{Slug: item.Slug, Title: item.Title, URL: item.URL, Cover: item.CoverURL})
+	}
+
 	// Calculate confidence score based on data completeness
 	response.ConfidenceScore = s.calculateConfidenceScoreByDay(response)
 
@@ -221,16 +221,60 @@ func (s *ScheduleService) GetScheduleByDay(inputDay string) (*models.ScheduleByD
 	return response, nil
 }
 
-// getDayForTitle determines release day consistently based on title
-func (s *ScheduleService) getDayForTitle(title string) string {
-	days := []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
-	var sum int
-	// Menjumlahkan nilai byte dari judul untuk mendapatkan angka yang konsisten
-	for _, char := range title {
-		sum += int(char)
+// convertSchedule moves every entry to its day and release time in loc and sets NextAir.
+// Entry dengan jam rilis yang tidak valid tetap di harinya tanpa NextAir.
+func convertSchedule(schedule map[string][]models.ReleaseEntry, now time.Time, loc *time.Location) map[string][]models.ReleaseEntry {
+	converted := make(map[string][]models.ReleaseEntry, len(scheduleDays))
+	for _, day := range scheduleDays {
+		converted[day] = []models.ReleaseEntry{}
+	}
+	for _, day := range scheduleDays {
+		for _, entry := range schedule[day] {
+			targetDay := day
+			if air, ok := nextAiring(day, entry.ReleaseTime, now, loc); ok {
+				targetDay = air.Day
+				entry.ReleaseTime = air.ReleaseTime
+				entry.NextAir = air.NextAir
+			}
+			converted[targetDay] = append(converted[targetDay], entry)
+		}
+	}
+	return converted
+}
+
+// ongoingType is the type of every drama on the ongoing page, yang hanya berisi serial
+const ongoingType = "TV"
+
+// previewScore returns the rating shown on a drama preview, atau "N/A" jika tidak ada
+func previewScore(e *colly.HTMLElement) string {
+	if score := strings.TrimSpace(e.ChildText(".icon-star.imdb")); score != "" {
+		return score
+	}
+	return "N/A"
+}
+
+// scheduleEstimate is the estimated release day and time of one drama
+type scheduleEstimate struct {
+	// Day and ReleaseTime ("HH:MM") are in WIB, zona waktu situs
+	Day         string
+	ReleaseTime string
+}
+
+// estimateSchedule derives the release day and time of a drama from its slug. Situs
+// sumber tidak mencantumkan jadwal, sehingga nilainya diturunkan dari hash slug: sama
+// di setiap request dan di semua endpoint jadwal (termasuk home), tetapi tetap perkiraan.
+func estimateSchedule(slug string) scheduleEstimate {
+	hash := fnv.New32a()
+	hash.Write([]byte(slug))
+	sum := hash.Sum32()
+
+	day := scheduleDays[sum%7]
+	minuteOfDay := (sum / 7) % (24 * 60)
+
+	return scheduleEstimate{
+		Day:         day,
+		ReleaseTime: fmt.Sprintf("%02d:%02d", minuteOfDay/60, minuteOfDay%60),
 	}
-	// Modulo 7 akan selalu menghasilkan angka 0-6
-	return days[sum%len(days)]
 }
 
 // calculateConfidenceScoreByDay calculates confidence score for schedule by day response
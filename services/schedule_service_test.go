@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nabilulilalbab/dramaqu/catalog"
+)
+
+// newScheduleTestServer serves an ongoing page listing count dramas
+func newScheduleTestServer(t *testing.T, count int) *httptest.Server {
+	var page strings.Builder
+	page.WriteString(`<html><body>`)
+	for i := 1; i <= count; i++ {
+		fmt.Fprintf(&page, `<article class="movie-preview"><span class="movie-title"><a href="/drama-%d/">Drama %d</a></span><img class="keremiya-image" src="/cover-%d.jpg"><span class="icon-star imdb">%d.5</span></article>`, i, i, i, i%10)
+	}
+	page.WriteString(`</body></html>`)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(page.String()))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestEstimateScheduleIsDeterministic(t *testing.T) {
+	first := estimateSchedule("judul-drama")
+	if again := estimateSchedule("judul-drama"); again != first {
+		t.Errorf("estimate changed between calls: %+v, %+v", first, again)
+	}
+	if _, ok := weeklyStart(startOfWeek(time.Now()), first.Day, first.ReleaseTime); !ok {
+		t.Errorf("invalid day or release time: %+v", first)
+	}
+}
+
+func TestScheduleEndpointsAgree(t *testing.T) {
+	srv := newScheduleTestServer(t, 20)
+	s := NewScheduleService(catalog.New(), localSite(srv.URL))
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	schedule, err := s.GetReleaseSchedule(context.Background(), tokyo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := s.GetReleaseSchedule(context.Background(), tokyo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !schedule.Estimated {
+		t.Error("release schedule must be marked as estimated")
+	}
+
+	total := 0
+	for _, day := range scheduleDays {
+		byDay, err := s.GetScheduleByDay(context.Background(), day, tokyo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !byDay.Estimated {
+			t.Errorf("%s: schedule by day must be marked as estimated", day)
+		}
+		if len(byDay.Data) != len(schedule.Data[day]) {
+			t.Errorf("%s: by-day has %d entries, schedule has %d", day, len(byDay.Data), len(schedule.Data[day]))
+			continue
+		}
+		// Urutan di dalam satu hari boleh berbeda, isinya harus sama
+		bySlug := make(map[string]string, len(byDay.Data))
+		for _, got := range byDay.Data {
+			bySlug[got.Slug] = got.ReleaseTime + " " + got.NextAir
+		}
+		for i, entry := range schedule.Data[day] {
+			total++
+			// Skor diambil dari halaman, bukan diperkirakan
+			var n int
+			fmt.Sscanf(entry.Slug, "drama-%d", &n)
+			if want := fmt.Sprintf("%d.5", n%10); entry.Score != want || entry.Type != "TV" {
+				t.Errorf("%s: score %q type %q, want scraped score %q and type TV", entry.Slug, entry.Score, entry.Type, want)
+			}
+			if repeat := again.Data[day][i]; repeat.Slug != entry.Slug || repeat.ReleaseTime != entry.ReleaseTime || repeat.Score != entry.Score {
+				t.Errorf("%s: schedule changed between requests: %+v, %+v", day, entry, repeat)
+			}
+			if got, want := bySlug[entry.Slug], entry.ReleaseTime+" "+entry.NextAir; got != want {
+				t.Errorf("%s %s: by-day %q, schedule %q", day, entry.Slug, got, want)
+			}
+		}
+	}
+	if total != 20 {
+		t.Errorf("got %d entries, want 20", total)
+	}
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"strings"
+	"time"
+)
+
+// dayAliases maps Indonesian day names to the English schedule days
+var dayAliases = map[string]string{
+	"senin":  "Monday",
+	"selasa": "Tuesday",
+	"rabu":   "Wednesday",
+	"kamis":  "Thursday",
+	"jumat":  "Friday",
+	"jum'at": "Friday",
+	"sabtu":  "Saturday",
+	"minggu": "Sunday",
+	"ahad":   "Sunday",
+}
+
+// ParseScheduleDay returns the English schedule day ("Monday") of an English or
+// Indonesian day name, case-insensitive
+func ParseScheduleDay(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if day, ok := dayAliases[name]; ok {
+		return day, true
+	}
+	for _, day := range scheduleDays {
+		if strings.EqualFold(day, name) {
+			return day, true
+		}
+	}
+	return "", false
+}
+
+// airing is the next broadcast of a schedule entry in the requested timezone
+type airing struct {
+	Day         string
+	ReleaseTime string
+	NextAir     string
+}
+
+// nextAiring converts a site day and "HH:MM" release time (WIB) to the next broadcast
+// after now in loc. Hari bisa bergeser jika jam rilis melewati tengah malam di loc.
+func nextAiring(day, releaseTime string, now time.Time, loc *time.Location) (airing, bool) {
+	next, ok := nextAirTime(day, releaseTime, now)
+	if !ok {
+		return airing{}, false
+	}
+	local := next.In(loc)
+	return airing{
+		Day:         local.Weekday().String(),
+		ReleaseTime: local.Format("15:04"),
+		NextAir:     local.Format(time.RFC3339),
+	}, true
+}
+
+// nextAirTime returns the first broadcast of a weekly site day and release time at or after now
+func nextAirTime(day, releaseTime string, now time.Time) (time.Time, bool) {
+	now = now.In(siteLocation)
+	start, ok := weeklyStart(startOfWeek(now), day, releaseTime)
+	if !ok {
+		return time.Time{}, false
+	}
+	if start.Before(now) {
+		start = start.AddDate(0, 0, 7)
+	}
+	return start, true
+}
+
+// scheduleLocation returns loc, or the site timezone when loc is nil
+func scheduleLocation(loc *time.Location) *time.Location {
+	if loc == nil {
+		return siteLocation
+	}
+	return loc
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nabilulilalbab/dramaqu/models"
+)
+
+func TestParseScheduleDay(t *testing.T) {
+	tests := map[string]string{
+		"monday": "Monday",
+		"SUNDAY": "Sunday",
+		"senin":  "Monday",
+		"Jumat":  "Friday",
+		"jum'at": "Friday",
+		"minggu": "Sunday",
+		"ahad":   "Sunday",
+		"":       "",
+		"funday": "",
+	}
+	for name, want := range tests {
+		got, ok := ParseScheduleDay(name)
+		if got != want || ok != (want != "") {
+			t.Errorf("ParseScheduleDay(%q) = %q, %v, want %q", name, got, ok, want)
+		}
+	}
+}
+
+func TestNextAiring(t *testing.T) {
+	jayapura := time.FixedZone("WIT", 9*60*60)
+	london := time.FixedZone("BST", 1*60*60)
+	// Senin 19 Oktober 2026 pukul 12:00 WIB
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, siteLocation)
+
+	tests := []struct {
+		name             string
+		day, releaseTime string
+		loc              *time.Location
+		want             airing
+	}{
+		{"later today", "Monday", "20:30", siteLocation, airing{"Monday", "20:30", "2026-10-19T20:30:00+07:00"}},
+		{"already aired rolls to next week", "Monday", "07:00", siteLocation, airing{"Monday", "07:00", "2026-10-26T07:00:00+07:00"}},
+		{"crosses midnight eastwards", "Monday", "23:30", jayapura, airing{"Tuesday", "01:30", "2026-10-20T01:30:00+09:00"}},
+		{"crosses midnight westwards", "Wednesday", "02:00", london, airing{"Tuesday", "20:00", "2026-10-20T20:00:00+01:00"}},
+		{"sunday wraps to monday", "Sunday", "23:00", jayapura, airing{"Monday", "01:00", "2026-10-26T01:00:00+09:00"}},
+	}
+	for _, tt := range tests {
+		got, ok := nextAiring(tt.day, tt.releaseTime, now, tt.loc)
+		if !ok || got != tt.want {
+			t.Errorf("%s: nextAiring(%q, %q) = %+v, %v, want %+v", tt.name, tt.day, tt.releaseTime, got, ok, tt.want)
+		}
+	}
+	if _, ok := nextAiring("Monday", "Unknown", now, siteLocation); ok {
+		t.Error("nextAiring with invalid release time succeeded")
+	}
+}
+
+func TestConvertSchedule(t *testing.T) {
+	jayapura := time.FixedZone("WIT", 9*60*60)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, siteLocation)
+	schedule := map[string][]models.ReleaseEntry{
+		"Monday": {
+			{Slug: "malam", ReleaseTime: "23:30"},
+			{Slug: "sore", ReleaseTime: "17:00"},
+			{Slug: "rusak", ReleaseTime: "Unknown"},
+		},
+	}
+
+	got := convertSchedule(schedule, now, jayapura)
+	if len(got) != len(scheduleDays) {
+		t.Fatalf("got %d days, want %d", len(got), len(scheduleDays))
+	}
+	monday, tuesday := got["Monday"], got["Tuesday"]
+	if len(monday) != 2 || monday[0].Slug != "sore" || monday[0].ReleaseTime != "19:00" || monday[1].Slug != "rusak" || monday[1].NextAir != "" {
+		t.Errorf("Monday = %+v", monday)
+	}
+	if len(tuesday) != 1 || tuesday[0].Slug != "malam" || tuesday[0].ReleaseTime != "01:30" || tuesday[0].NextAir != "2026-10-20T01:30:00+09:00" {
+		t.Errorf("Tuesday = %+v", tuesday)
+	}
+	if got["Sunday"] == nil {
+		t.Error("Sunday is nil, want empty slice")
+	}
+}
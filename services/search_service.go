@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/url"
@@ -9,19 +10,23 @@ import (
 	"time"
 
 	"github.com/gocolly/colly/v2"
+	"github.com/nabilulilalbab/dramaqu/catalog"
 	"github.com/nabilulilalbab/dramaqu/models"
 )
 
-type SearchService struct{}
+type SearchService struct {
+	catalog *catalog.Catalog
+	site    Site
+}
 
-func NewSearchService() *SearchService {
-	return &SearchService{}
+func NewSearchService(cat *catalog.Catalog, site Site) *SearchService {
+	return &SearchService{catalog: cat, site: site}
 }
 
 // SearchDrama scrapes and returns search results with the exact same logic as the test
-func (s *SearchService) SearchDrama(query string, page int) (*models.SearchResponse, error) {
+func (s *SearchService) SearchDrama(ctx context.Context, query string, page int) (*models.SearchResponse, error) {
 	// Buat URL pencarian yang benar
-	baseURL := "https://dramaqu.ad/"
+	baseURL := s.site.URL("/")
 	targetURL := fmt.Sprintf("%s?s=%s", baseURL, url.QueryEscape(query))
 	if page > 1 {
 		targetURL = fmt.Sprintf("%spage/%d/?s=%s", baseURL, page, url.QueryEscape(query))
@@ -35,10 +40,12 @@ func (s *SearchService) SearchDrama(query string, page int) (*models.SearchRespo
 	}
 
 	c := colly.NewCollector(
-		colly.AllowedDomains("dramaqu.ad"),
+		colly.AllowedDomains(s.site.Domains()...),
+		colly.StdlibContext(ctx),
 		colly.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.0.0 Safari/537.36"),
 	)
 	c.SetRequestTimeout(30 * time.Second)
+	s.site.guard(c)
 
 	c.OnHTML("article.movie-preview", func(e *colly.HTMLElement) {
 		entry := models.SearchDetail{}
@@ -85,11 +92,14 @@ func (s *SearchService) SearchDrama(query string, page int) (*models.SearchRespo
 		log.Printf("Error saat request ke %s: %v", r.Request.URL, err)
 	})
 
-	err := c.Visit(targetURL)
-	if err != nil {
-		return nil, fmt.Errorf("gagal mengunjungi URL: %v", err)
+	if err := visit(ctx, c, targetURL); err != nil {
+		return nil, err
+	}
+
+	// Simpan hasil scraping ke catalog untuk saran pencarian
+	for _, item := range response.Data {
+		s.catalog.UpsertDrama(catalog.Drama{Slug: item.Slug, Title: item.Judul, URL: item.URL, Cover: item.Cover})
 	}
-	c.Wait()
 
 	// Calculate confidence score based on data completeness
 	response.ConfidenceScore = s.calculateConfidenceScore(response)
@@ -108,6 +118,32 @@ func (s *SearchService) SearchDrama(query string, page int) (*models.SearchRespo
 	return response, nil
 }
 
+// Suggest returns title suggestions from the in-memory catalog without scraping
+func (s *SearchService) Suggest(query string, limit int) *models.SuggestResponse {
+	response := &models.SuggestResponse{
+		ConfidenceScore: 0.0,
+		Message:         "Tidak ada saran yang cocok",
+		Source:          "catalog",
+		Data:            []models.SuggestItem{},
+	}
+
+	for _, suggestion := range s.catalog.Suggest(query, limit) {
+		response.Data = append(response.Data, models.SuggestItem{
+			Judul: suggestion.Title,
+			URL:   suggestion.URL,
+			Slug:  suggestion.Slug,
+			Cover: suggestion.Cover,
+		})
+	}
+
+	if len(response.Data) > 0 {
+		response.ConfidenceScore = 1.0
+		response.Message = "Data berhasil diambil"
+	}
+
+	return response
+}
+
 // calculateConfidenceScore calculates confidence score for search response
 func (s *SearchService) calculateConfidenceScore(response *models.SearchResponse) float64 {
 	if len(response.Data) == 0 {
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gocolly/colly/v2"
+	"github.com/nabilulilalbab/dramaqu/urlguard"
+)
+
+// Site is the upstream site every service scrapes, diatur lewat BASE_URL dan ALLOWED_HOSTS
+type Site struct {
+	// BaseURL is the site root without trailing slash, contoh https://dramaqu.ad
+	BaseURL string
+	// Hosts are the hostnames collectors may visit; kosong berarti host dari BaseURL
+	Hosts []string
+	// Transport carries every scrape request; NewSite memakai transport urlguard
+	// yang hanya dapat menjangkau alamat publik
+	Transport http.RoundTripper
+}
+
+// NewSite creates a Site from the configured base URL and allowed hosts
+func NewSite(baseURL string, hosts []string) Site {
+	return Site{
+		BaseURL:   strings.TrimSuffix(baseURL, "/"),
+		Hosts:     hosts,
+		Transport: urlguard.NewTransport(),
+	}
+}
+
+// URL returns the absolute URL of a path on the site, contoh URL("/category/ongoing-drama/")
+func (s Site) URL(path string) string {
+	return s.BaseURL + path
+}
+
+// Referer is the Referer header of requests that must look like they come from the site
+func (s Site) Referer() string {
+	return s.URL("/")
+}
+
+// Domains returns the hostnames collectors may visit
+func (s Site) Domains() []string {
+	if len(s.Hosts) > 0 {
+		return s.Hosts
+	}
+	if u, err := url.Parse(s.BaseURL); err == nil && u.Hostname() != "" {
+		return []string{u.Hostname()}
+	}
+	return nil
+}
+
+// guard routes the requests of a collector through the site transport
+func (s Site) guard(c *colly.Collector) {
+	if s.Transport != nil {
+		c.WithTransport(s.Transport)
+	}
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// testSite is the default upstream site used by tests that never reach the network
+var testSite = NewSite("https://dramaqu.ad", nil)
+
+// localSite returns a Site whose collectors may reach httptest servers,
+// yang berjalan di 127.0.0.1 dan karenanya ditolak transport urlguard
+func localSite(baseURL string) Site {
+	site := NewSite(baseURL, nil)
+	site.Transport = http.DefaultTransport
+	return site
+}
+
+func TestSite(t *testing.T) {
+	mirror := NewSite("https://mirror.example/", nil)
+	if got := mirror.URL(ongoingPath); got != "https://mirror.example/category/ongoing-drama/" {
+		t.Errorf("URL = %q", got)
+	}
+	if got := mirror.Referer(); got != "https://mirror.example/" {
+		t.Errorf("Referer = %q", got)
+	}
+	if got := mirror.Domains(); !reflect.DeepEqual(got, []string{"mirror.example"}) {
+		t.Errorf("Domains = %q, want host of BaseURL", got)
+	}
+	hosts := []string{"mirror.example", "www.mirror.example"}
+	if got := NewSite("https://mirror.example", hosts).Domains(); !reflect.DeepEqual(got, hosts) {
+		t.Errorf("Domains = %q, want configured hosts", got)
+	}
+}
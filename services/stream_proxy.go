@@ -0,0 +1,272 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nabilulilalbab/dramaqu/resolver"
+	"github.com/nabilulilalbab/dramaqu/urlguard"
+)
+
+// StreamProxyPath is the route that rewritten playlist URIs point to
+const StreamProxyPath = "/api/v1/stream/proxy"
+
+const (
+	// proxyGrantTTL is how long a host produced by a resolver may be proxied
+	proxyGrantTTL = 6 * time.Hour
+	// maxProxyGrants bounds the number of granted hosts kept in memory
+	maxProxyGrants = 1024
+	// maxPlaylistSize limits how much of an m3u8 playlist is read for rewriting
+	maxPlaylistSize = 2 << 20
+)
+
+// ErrInvalidProxyURL is returned when the proxy target is not an absolute http(s) URL
+var ErrInvalidProxyURL = errors.New("URL proxy tidak valid")
+
+// ErrProxyHostNotAllowed is returned when the target host was not produced by a resolver
+var ErrProxyHostNotAllowed = errors.New("host tidak diizinkan untuk proxy")
+
+// proxyGrantPolicy accepts any host: host ditentukan oleh resolver atau playlist,
+// yang diperiksa hanya bentuk URL dan alamat IP-nya
+var proxyGrantPolicy = urlguard.Policy{AllowHost: func(string) bool { return true }}
+
+// reURIAttribute matches URI="..." attributes in HLS tags (EXT-X-KEY, EXT-X-MAP, EXT-X-MEDIA, ...)
+var reURIAttribute = regexp.MustCompile(`URI="([^"]*)"`)
+
+// proxyGrant stores the headers a proxied host requires
+type proxyGrant struct {
+	headers map[string]string
+	expires time.Time
+}
+
+// ProxyResponse is an upstream response ready to be relayed to the client.
+// Playlist terisi untuk m3u8 yang sudah di-rewrite; selain itu Body di-stream apa adanya.
+type ProxyResponse struct {
+	StatusCode    int
+	ContentType   string
+	ContentLength int64
+	Header        http.Header
+	Playlist      []byte
+	Body          io.ReadCloser
+}
+
+// newProxyClient creates the HTTP client used for proxied media.
+// Tidak memakai timeout total karena segmen video di-stream ke client.
+// Setiap koneksi dan redirect diperiksa urlguard agar hanya alamat publik yang dijangkau.
+func newProxyClient() *http.Client {
+	transport := urlguard.NewTransport()
+	transport.ResponseHeaderTimeout = 20 * time.Second
+	return &http.Client{
+		Transport:     transport,
+		CheckRedirect: urlguard.CheckRedirect,
+	}
+}
+
+// allowStream grants proxy access to every host referenced by a resolved stream
+func (s *StreamService) allowStream(ctx context.Context, stream *resolver.Stream) {
+	for _, src := range stream.Sources {
+		s.allowURL(ctx, src.URL, stream.Headers)
+	}
+	for _, sub := range stream.Subtitles {
+		s.allowURL(ctx, sub.URL, stream.Headers)
+	}
+}
+
+// allowURL grants proxy access to the host of rawURL with the given headers.
+// URL yang gagal divalidasi urlguard (IP literal, port lain, alamat non-publik) tidak diizinkan.
+func (s *StreamService) allowURL(ctx context.Context, rawURL string, headers map[string]string) bool {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || parsedURL.Host == "" {
+		return false
+	}
+	host := strings.ToLower(parsedURL.Host)
+
+	// Host yang sudah diizinkan cukup diperpanjang, tanpa resolve DNS ulang untuk setiap segmen
+	if _, ok := s.grantFor(host); !ok {
+		if err := s.checkURL(ctx, rawURL); err != nil {
+			log.Printf("Host %s tidak diizinkan untuk proxy: %v", host, err)
+			return false
+		}
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.grants[host]; !ok && len(s.grants) >= maxProxyGrants {
+		s.pruneGrants(now)
+	}
+	s.grants[host] = proxyGrant{
+		headers: headers,
+		expires: now.Add(proxyGrantTTL),
+	}
+	return true
+}
+
+// pruneGrants removes expired grants, and the grant closest to expiry if the map is still full.
+// Dipanggil dengan s.mu terkunci.
+func (s *StreamService) pruneGrants(now time.Time) {
+	oldest := ""
+	for host, grant := range s.grants {
+		if now.After(grant.expires) {
+			delete(s.grants, host)
+			continue
+		}
+		if oldest == "" || grant.expires.Before(s.grants[oldest].expires) {
+			oldest = host
+		}
+	}
+	if len(s.grants) >= maxProxyGrants && oldest != "" {
+		delete(s.grants, oldest)
+	}
+}
+
+// ProxyAllowed reports whether host currently has a proxy grant from a resolved stream
+func (s *StreamService) ProxyAllowed(host string) bool {
+	_, ok := s.grantFor(host)
+	return ok
+}
+
+// grantFor returns the proxy grant of a host if it has not expired
+func (s *StreamService) grantFor(host string) (proxyGrant, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	host = strings.ToLower(host)
+	grant, ok := s.grants[host]
+	if !ok {
+		return proxyGrant{}, false
+	}
+	if time.Now().After(grant.expires) {
+		delete(s.grants, host)
+		return proxyGrant{}, false
+	}
+	return grant, true
+}
+
+// Proxy fetches a playlist, segment or subtitle from a host produced by a resolver.
+// Playlist m3u8 di-rewrite agar semua URI (variant, segmen, key, map) melewati proxy
+// dengan link yang ditandatangani untuk clientIP.
+func (s *StreamService) Proxy(ctx context.Context, target, rangeHeader, clientIP string) (*ProxyResponse, error) {
+	targetURL, err := url.Parse(target)
+	if err != nil || (targetURL.Scheme != "http" && targetURL.Scheme != "https") || targetURL.Host == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidProxyURL, target)
+	}
+
+	grant, ok := s.grantFor(targetURL.Host)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProxyHostNotAllowed, targetURL.Host)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, targetURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range grant.headers {
+		req.Header.Set(key, value)
+	}
+	if rangeHeader != "" {
+		req.Header.Set("Range", rangeHeader)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil %s: %v", targetURL.Host, err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		resp.Body.Close()
+		return nil, fmt.Errorf("upstream %s mengembalikan status %d", targetURL.Host, resp.StatusCode)
+	}
+
+	result := &ProxyResponse{
+		StatusCode:    resp.StatusCode,
+		ContentType:   resp.Header.Get("Content-Type"),
+		ContentLength: resp.ContentLength,
+		Header:        http.Header{},
+	}
+	for _, key := range []string{"Content-Range", "Accept-Ranges", "Cache-Control", "Last-Modified"} {
+		if value := resp.Header.Get(key); value != "" {
+			result.Header.Set(key, value)
+		}
+	}
+
+	if !isPlaylist(resp.Request.URL, result.ContentType) {
+		result.Body = resp.Body
+		return result, nil
+	}
+
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPlaylistSize))
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca playlist: %v", err)
+	}
+	// URI relatif di-resolve terhadap URL akhir setelah redirect
+	result.Playlist = []byte(s.rewritePlaylist(ctx, string(body), resp.Request.URL, grant.headers, clientIP))
+	result.ContentType = "application/vnd.apple.mpegurl"
+	result.ContentLength = int64(len(result.Playlist))
+	result.Header.Del("Content-Range")
+	result.Header.Del("Accept-Ranges")
+	return result, nil
+}
+
+// rewritePlaylist rewrites every URI of an m3u8 playlist to go through the proxy.
+// Host yang dirujuk oleh playlist ikut diizinkan dengan header yang sama; URI yang
+// ditolak urlguard dibiarkan apa adanya sehingga tidak bisa diambil lewat proxy.
+func (s *StreamService) rewritePlaylist(ctx context.Context, playlist string, base *url.URL, headers map[string]string, clientIP string) string {
+	rewrite := func(rawURI string) string {
+		ref, err := url.Parse(strings.TrimSpace(rawURI))
+		if err != nil || strings.HasPrefix(rawURI, "data:") {
+			return rawURI
+		}
+		absolute := base.ResolveReference(ref)
+		if absolute.Scheme != "http" && absolute.Scheme != "https" {
+			return rawURI
+		}
+		if !s.allowURL(ctx, absolute.String(), headers) {
+			return rawURI
+		}
+		return s.proxyURL(absolute.String(), clientIP)
+	}
+
+	var out strings.Builder
+	scanner := bufio.NewScanner(strings.NewReader(playlist))
+	scanner.Buffer(make([]byte, 64*1024), maxPlaylistSize)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case strings.TrimSpace(line) == "":
+		case strings.HasPrefix(line, "#"):
+			line = reURIAttribute.ReplaceAllStringFunc(line, func(attr string) string {
+				return `URI="` + rewrite(reURIAttribute.FindStringSubmatch(attr)[1]) + `"`
+			})
+		default:
+			line = rewrite(line)
+		}
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+	return out.String()
+}
+
+// proxyURL returns the signed proxy route for a media URL
+func (s *StreamService) proxyURL(target, clientIP string) string {
+	return s.signer.Sign(StreamProxyPath+"?url="+url.QueryEscape(target), clientIP)
+}
+
+// isPlaylist reports whether a response is an HLS playlist
+func isPlaylist(u *url.URL, contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	if strings.Contains(contentType, "mpegurl") {
+		return true
+	}
+	return strings.EqualFold(path.Ext(u.Path), ".m3u8")
+}
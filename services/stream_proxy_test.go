@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nabilulilalbab/dramaqu/resolver"
+	"github.com/nabilulilalbab/dramaqu/signer"
+	"github.com/nabilulilalbab/dramaqu/urlguard"
+)
+
+var testSigner = signer.New([]byte("test-secret"), time.Hour, true)
+
+// testValidator resolves every host to a public address, except *.internal.test
+var testValidator = urlguard.New(func(_ context.Context, host string) ([]net.IP, error) {
+	if strings.HasSuffix(host, ".internal.test") {
+		return []net.IP{net.ParseIP("10.0.0.2")}, nil
+	}
+	return []net.IP{net.ParseIP("93.184.216.34")}, nil
+})
+
+func newTestStreamService() *StreamService {
+	return NewStreamService(resolver.NewRegistry(nil), testSigner, testValidator)
+}
+
+// proxiedTargets verifies every proxy URI of a rewritten playlist and returns their targets
+func proxiedTargets(t *testing.T, playlist, clientIP string) []string {
+	t.Helper()
+	var targets []string
+	for _, line := range strings.Split(playlist, "\n") {
+		uris := []string{line}
+		if strings.HasPrefix(line, "#") {
+			uris = nil
+			for _, m := range reURIAttribute.FindAllStringSubmatch(line, -1) {
+				uris = append(uris, m[1])
+			}
+		}
+		for _, uri := range uris {
+			if uri == "" {
+				continue
+			}
+			u, err := url.Parse(uri)
+			if err != nil || u.Path != StreamProxyPath {
+				t.Errorf("URI %q does not point to the proxy", uri)
+				continue
+			}
+			if err := testSigner.Verify(u, clientIP); err != nil {
+				t.Errorf("URI %q is not validly signed: %v", uri, err)
+			}
+			targets = append(targets, u.Query().Get("url"))
+		}
+	}
+	return targets
+}
+
+func TestRewriteMasterPlaylist(t *testing.T) {
+	s := newTestStreamService()
+	base, _ := url.Parse("https://drmq.stream/hls/judul-02/master.m3u8")
+	headers := map[string]string{"Referer": "https://drmq.stream/"}
+
+	got := s.rewritePlaylist(context.Background(), loadFixture(t, "hls_master.m3u8"), base, headers, "10.0.0.7")
+
+	want := []string{
+		"https://drmq.stream/hls/judul-02/subs/id.m3u8",
+		"https://drmq.stream/hls/judul-02/720/index.m3u8",
+		"https://cdn2.example-cdn.net/hls/480/index.m3u8?token=abc&e=1724400000",
+	}
+	if targets := proxiedTargets(t, got, "10.0.0.7"); !reflect.DeepEqual(targets, want) {
+		t.Errorf("proxied targets = %q, want %q", targets, want)
+	}
+	if !strings.Contains(got, `#EXT-X-STREAM-INF:BANDWIDTH=2800000,RESOLUTION=1280x720,SUBTITLES="subs"`) {
+		t.Errorf("tags without URI must be kept as is\n%s", got)
+	}
+
+	grant, ok := s.grantFor("cdn2.example-cdn.net")
+	if !ok || grant.headers["Referer"] != "https://drmq.stream/" {
+		t.Errorf("host referenced by playlist must inherit the resolver headers, got %+v %v", grant, ok)
+	}
+}
+
+func TestRewriteMediaPlaylistKeysAndMap(t *testing.T) {
+	s := newTestStreamService()
+	base, _ := url.Parse("https://cdn.example.net/hls/720/index.m3u8")
+
+	got := s.rewritePlaylist(context.Background(), loadFixture(t, "hls_media.m3u8"), base, nil, "10.0.0.7")
+
+	want := []string{
+		"https://cdn.example.net/keys/ep02.key",
+		"https://cdn.example.net/hls/720/init.mp4",
+		"https://cdn.example.net/hls/720/seg-000.m4s",
+		"https://cdn.example.net/hls/720/seg-001.m4s?sig=xyz",
+	}
+	if targets := proxiedTargets(t, got, "10.0.0.7"); !reflect.DeepEqual(targets, want) {
+		t.Errorf("proxied targets = %q, want %q", targets, want)
+	}
+	for _, tag := range []string{",IV=0x1a2b3c4d5e6f70819a2b3c4d5e6f7081\n", "#EXTINF:6.000,\n", "#EXT-X-ENDLIST\n"} {
+		if !strings.Contains(got, tag) {
+			t.Errorf("rewritten playlist missing %q\n%s", tag, got)
+		}
+	}
+}
+
+func TestProxyRejectsHostsNotProducedByResolver(t *testing.T) {
+	s := newTestStreamService()
+
+	_, err := s.Proxy(context.Background(), "https://evil.example/secret.m3u8", "", "")
+	if !errors.Is(err, ErrProxyHostNotAllowed) {
+		t.Fatalf("Proxy error = %v, want ErrProxyHostNotAllowed", err)
+	}
+
+	for _, target := range []string{"file:///etc/passwd", "https:///tanpa-host.m3u8", "%zz"} {
+		if _, err := s.Proxy(context.Background(), target, "", ""); !errors.Is(err, ErrInvalidProxyURL) {
+			t.Errorf("Proxy(%q) error = %v, want ErrInvalidProxyURL", target, err)
+		}
+	}
+}
+
+func TestRewritePlaylistRejectsNonPublicURIs(t *testing.T) {
+	s := newTestStreamService()
+	base, _ := url.Parse("https://cdn.example.net/hls/720/index.m3u8")
+	playlist := "#EXTM3U\n" +
+		"#EXT-X-KEY:METHOD=AES-128,URI=\"http://169.254.169.254/latest/meta-data/\"\n" +
+		"#EXTINF:6.0,\nhttp://10.0.0.1/seg-000.ts\n" +
+		"#EXTINF:6.0,\nhttps://cdn.internal.test/seg-001.ts\n" +
+		"#EXTINF:6.0,\nhttps://cdn.example.net:8443/seg-002.ts\n" +
+		"#EXTINF:6.0,\nseg-003.ts\n"
+
+	got := s.rewritePlaylist(context.Background(), playlist, base, nil, "")
+
+	for _, raw := range []string{"http://169.254.169.254/latest/meta-data/", "http://10.0.0.1/seg-000.ts", "https://cdn.internal.test/seg-001.ts", "https://cdn.example.net:8443/seg-002.ts"} {
+		if !strings.Contains(got, raw) {
+			t.Errorf("rejected URI %q must be left unproxied\n%s", raw, got)
+		}
+	}
+	if n := strings.Count(got, StreamProxyPath); n != 1 || !strings.Contains(got, url.QueryEscape("https://cdn.example.net/hls/720/seg-003.ts")) {
+		t.Errorf("only the public segment must be proxied, got %d proxied URIs\n%s", n, got)
+	}
+	for _, host := range []string{"169.254.169.254", "10.0.0.1", "cdn.internal.test", "cdn.example.net:8443"} {
+		if s.ProxyAllowed(host) {
+			t.Errorf("host %s must not be granted", host)
+		}
+	}
+	if !s.ProxyAllowed("cdn.example.net") {
+		t.Error("public host must be granted")
+	}
+}
+
+func TestProxyGrantsAreBounded(t *testing.T) {
+	s := newTestStreamService()
+	s.grants["expired.example"] = proxyGrant{expires: time.Now().Add(-time.Minute)}
+	for i := 0; len(s.grants) < maxProxyGrants; i++ {
+		s.grants[fmt.Sprintf("host-%d.example", i)] = proxyGrant{expires: time.Now().Add(time.Duration(i+1) * time.Minute)}
+	}
+
+	if !s.allowURL(context.Background(), "https://new.example/a.m3u8", nil) {
+		t.Fatal("allowURL rejected a public URL")
+	}
+	if len(s.grants) > maxProxyGrants {
+		t.Errorf("grants = %d, want at most %d", len(s.grants), maxProxyGrants)
+	}
+	if _, ok := s.grants["expired.example"]; ok {
+		t.Error("expired grant must be swept")
+	}
+	if !s.ProxyAllowed("new.example") || !s.ProxyAllowed("host-0.example") {
+		t.Error("new and unexpired grants must be kept while there is room")
+	}
+
+	// Map penuh tanpa grant kedaluwarsa: grant yang paling cepat kedaluwarsa dibuang
+	s.allowURL(context.Background(), "https://newer.example/a.m3u8", nil)
+	if len(s.grants) > maxProxyGrants || s.ProxyAllowed("host-0.example") || !s.ProxyAllowed("newer.example") {
+		t.Errorf("full map must evict the grant closest to expiry (grants = %d)", len(s.grants))
+	}
+}
+
+func TestProxyForwardsHeadersAndStreamsSegments(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Referer") != "https://drmq.stream/" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/hls/index.m3u8":
+			w.Header().Set("Content-Type", "application/vnd.apple.mpegurl")
+			io.WriteString(w, "#EXTM3U\n#EXTINF:6.0,\nseg-000.ts\n#EXT-X-ENDLIST\n")
+		case "/hls/seg-000.ts":
+			if r.Header.Get("Range") != "bytes=0-3" {
+				t.Errorf("Range header not forwarded, got %q", r.Header.Get("Range"))
+			}
+			w.Header().Set("Content-Type", "video/mp2t")
+			w.Header().Set("Content-Range", "bytes 0-3/8")
+			w.WriteHeader(http.StatusPartialContent)
+			io.WriteString(w, "\x47\x40\x00\x10")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer upstream.Close()
+
+	s := newTestStreamService()
+	// Server test berada di 127.0.0.1 yang ditolak urlguard
+	s.checkURL = func(context.Context, string) error { return nil }
+	s.client = upstream.Client()
+	s.allowStream(context.Background(), &resolver.Stream{
+		Sources: []resolver.Source{{URL: upstream.URL + "/hls/index.m3u8", Type: resolver.TypeHLS}},
+		Headers: map[string]string{"Referer": "https://drmq.stream/"},
+	})
+
+	playlist, err := s.Proxy(context.Background(), upstream.URL+"/hls/index.m3u8", "", "10.0.0.7")
+	if err != nil {
+		t.Fatalf("Proxy playlist returned error: %v", err)
+	}
+	segmentURL := upstream.URL + "/hls/seg-000.ts"
+	if targets := proxiedTargets(t, string(playlist.Playlist), "10.0.0.7"); len(targets) != 1 || targets[0] != segmentURL {
+		t.Fatalf("segment URI not rewritten:\n%s", playlist.Playlist)
+	}
+
+	segment, err := s.Proxy(context.Background(), segmentURL, "bytes=0-3", "10.0.0.7")
+	if err != nil {
+		t.Fatalf("Proxy segment returned error: %v", err)
+	}
+	defer segment.Body.Close()
+	if segment.Playlist != nil || segment.Body == nil {
+		t.Fatal("segments must be streamed through Body, not buffered as a playlist")
+	}
+	if segment.StatusCode != http.StatusPartialContent || segment.Header.Get("Content-Range") != "bytes 0-3/8" {
+		t.Errorf("partial content not relayed: status %d, Content-Range %q", segment.StatusCode, segment.Header.Get("Content-Range"))
+	}
+	data, _ := io.ReadAll(segment.Body)
+	if len(data) != 4 {
+		t.Errorf("segment body length = %d, want 4", len(data))
+	}
+}
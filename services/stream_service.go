@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nabilulilalbab/dramaqu/models"
+	"github.com/nabilulilalbab/dramaqu/resolver"
+	"github.com/nabilulilalbab/dramaqu/signer"
+	"github.com/nabilulilalbab/dramaqu/urlguard"
+)
+
+// resolveTimeout limits how long one embed page may take to resolve
+const resolveTimeout = 20 * time.Second
+
+// StreamService resolves embed players into direct media URLs
+type StreamService struct {
+	registry *resolver.Registry
+	signer   *signer.Signer
+	client   *http.Client
+	// checkURL validates a URL before its host is granted proxy access
+	checkURL func(ctx context.Context, rawURL string) error
+
+	mu     sync.Mutex
+	grants map[string]proxyGrant
+}
+
+// NewStreamService creates a new instance of StreamService.
+// validator memeriksa setiap URL dari resolver atau playlist sebelum host-nya boleh di-proxy.
+func NewStreamService(registry *resolver.Registry, sig *signer.Signer, validator *urlguard.Validator) *StreamService {
+	return &StreamService{
+		registry: registry,
+		signer:   sig,
+		client:   newProxyClient(),
+		checkURL: func(ctx context.Context, rawURL string) error {
+			_, err := validator.Validate(ctx, rawURL, proxyGrantPolicy)
+			return err
+		},
+		grants: make(map[string]proxyGrant),
+	}
+}
+
+// Supports reports whether a resolver is registered for the embed host
+func (s *StreamService) Supports(host string) bool {
+	_, ok := s.registry.Lookup(host)
+	return ok
+}
+
+// Resolve extracts the direct sources of a single embed URL.
+// clientIP dipakai untuk menandatangani proxy_url jika link diikat ke IP.
+func (s *StreamService) Resolve(ctx context.Context, embedURL, clientIP string) (*models.StreamResolveResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	defer cancel()
+
+	stream, err := s.registry.Resolve(ctx, embedURL)
+	if err != nil {
+		return nil, err
+	}
+	s.allowStream(ctx, stream)
+
+	response := &models.StreamResolveResponse{
+		ConfidenceScore: 0.0, // Will be calculated later
+		Message:         "Data berhasil diambil",
+		Source:          stream.Resolver,
+		Data:            s.toResolvedStream(stream, clientIP),
+	}
+
+	response.ConfidenceScore = s.calculateConfidenceScore(&response.Data)
+	if response.ConfidenceScore < 0.5 {
+		response.Message = "Data berhasil diambil dengan confidence rendah"
+	} else if response.ConfidenceScore < 0.8 {
+		response.Message = "Data berhasil diambil dengan confidence sedang"
+	} else if response.ConfidenceScore < 1.0 {
+		response.Message = "Data berhasil diambil dengan confidence tinggi"
+	} else {
+		response.Message = "Data berhasil diambil dengan confidence sempurna"
+	}
+
+	return response, nil
+}
+
+// ResolveServers resolves every streaming server of an episode concurrently
+// and collects their subtitle tracks into detail.Subtitles.
+// Server dengan host yang belum didukung dibiarkan apa adanya.
+func (s *StreamService) ResolveServers(ctx context.Context, detail *models.EpisodeDetailResponse, clientIP string) {
+	var wg sync.WaitGroup
+	for i := range detail.StreamingServers {
+		server := &detail.StreamingServers[i]
+		if server.StreamingURL == "" || !s.Supports(server.ServerName) {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+			defer cancel()
+
+			stream, err := s.registry.Resolve(ctx, server.StreamingURL)
+			if err != nil {
+				log.Printf("Gagal resolve server %s: %v", server.ServerName, err)
+				server.ResolveError = err.Error()
+				return
+			}
+			s.allowStream(ctx, stream)
+			resolved := s.toResolvedStream(stream, clientIP)
+			server.Resolved = &resolved
+		}()
+	}
+	wg.Wait()
+
+	// Kumpulkan subtitle dari semua server, tanpa duplikat
+	seen := make(map[string]bool)
+	for _, sub := range detail.Subtitles {
+		seen[sub.URL] = true
+	}
+	for _, server := range detail.StreamingServers {
+		if server.Resolved == nil {
+			continue
+		}
+		for _, sub := range server.Resolved.Subtitles {
+			if seen[sub.URL] {
+				continue
+			}
+			seen[sub.URL] = true
+			detail.Subtitles = append(detail.Subtitles, sub)
+		}
+	}
+}
+
+// toResolvedStream converts a resolver stream into its JSON model with signed proxy URLs
+func (s *StreamService) toResolvedStream(stream *resolver.Stream, clientIP string) models.ResolvedStream {
+	resolved := models.ResolvedStream{
+		Resolver:       stream.Resolver,
+		EmbedURL:       stream.EmbedURL,
+		Sources:        []models.MediaSource{},
+		Subtitles:      []models.StreamSubtitle{},
+		Headers:        stream.Headers,
+		ProxyExpiresAt: s.signer.ExpiresAt().Format(time.RFC3339),
+	}
+	for _, src := range stream.Sources {
+		resolved.Sources = append(resolved.Sources, models.MediaSource{
+			URL:      src.URL,
+			Type:     src.Type,
+			Quality:  src.Quality,
+			ProxyURL: s.proxyURL(src.URL, clientIP),
+		})
+	}
+	for _, sub := range stream.Subtitles {
+		resolved.Subtitles = append(resolved.Subtitles, models.StreamSubtitle{
+			Language: sub.Language,
+			Label:    sub.Label,
+			Format:   sub.Format,
+			URL:      sub.URL,
+			ProxyURL: s.proxyURL(sub.URL, clientIP),
+		})
+	}
+	return resolved
+}
+
+// calculateConfidenceScore calculates confidence score for a resolved stream
+func (s *StreamService) calculateConfidenceScore(stream *models.ResolvedStream) float64 {
+	if len(stream.Sources) == 0 {
+		return 0.0
+	}
+
+	// Skor dihitung dalam persepuluhan agar 1.0 tepat tercapai
+	score := 7
+	// Kualitas diketahui membantu client memilih source
+	for _, src := range stream.Sources {
+		if src.Quality != "" {
+			score += 2
+			break
+		}
+	}
+	if len(stream.Subtitles) > 0 {
+		score++
+	}
+	return float64(score) / 10
+}
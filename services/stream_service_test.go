@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/nabilulilalbab/dramaqu/models"
+	"github.com/nabilulilalbab/dramaqu/resolver"
+)
+
+// stubResolver returns the same subtitle for every embed of its host
+type stubResolver struct{}
+
+func (stubResolver) Name() string    { return "stub" }
+func (stubResolver) Hosts() []string { return []string{"embed.test"} }
+
+func (stubResolver) Resolve(_ context.Context, _ resolver.Fetcher, embedURL *url.URL) (*resolver.Stream, error) {
+	return &resolver.Stream{
+		Sources: []resolver.Source{{URL: "https://cdn.test" + embedURL.Path + ".m3u8", Type: resolver.TypeHLS}},
+		Subtitles: []resolver.Subtitle{
+			{Language: "id", Label: "Indonesia", Format: "vtt", URL: "https://cdn.test/subs/ep02_id.vtt"},
+		},
+	}, nil
+}
+
+func TestResolveServersCollectsSubtitles(t *testing.T) {
+	s := NewStreamService(resolver.NewRegistry(nil, stubResolver{}), testSigner, testValidator)
+	detail := &models.EpisodeDetailResponse{
+		StreamingServers: []models.StreamingServer{
+			{ServerName: "embed.test", StreamingURL: "https://embed.test/e/1"},
+			{ServerName: "unknown.test", StreamingURL: "https://unknown.test/e/1"},
+			{ServerName: "embed.test", StreamingURL: "https://embed.test/e/2"},
+		},
+		Subtitles: []models.StreamSubtitle{},
+	}
+
+	s.ResolveServers(context.Background(), detail, "")
+
+	if detail.StreamingServers[0].Resolved == nil || detail.StreamingServers[2].Resolved == nil {
+		t.Fatal("servers on a supported host must be resolved")
+	}
+	if detail.StreamingServers[1].Resolved != nil || detail.StreamingServers[1].ResolveError != "" {
+		t.Error("servers on unsupported hosts must be left untouched")
+	}
+	if len(detail.Subtitles) != 1 {
+		t.Fatalf("subtitles = %+v, want one deduplicated track", detail.Subtitles)
+	}
+	sub := detail.Subtitles[0]
+	if sub.Language != "id" || sub.Format != "vtt" || sub.URL != "https://cdn.test/subs/ep02_id.vtt" || sub.ProxyURL == "" {
+		t.Errorf("subtitle = %+v", sub)
+	}
+	if _, ok := s.grantFor("cdn.test"); !ok {
+		t.Error("subtitle host must be allowed for the proxy and converter")
+	}
+}
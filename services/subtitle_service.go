@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/nabilulilalbab/dramaqu/subtitle"
+)
+
+// maxSubtitleSize limits how much of a subtitle file is read
+const maxSubtitleSize = 2 << 20
+
+// SubtitleService converts subtitles between SRT, ASS and WebVTT
+type SubtitleService struct {
+	stream *StreamService
+}
+
+// NewSubtitleService creates a new instance of SubtitleService
+func NewSubtitleService(stream *StreamService) *SubtitleService {
+	return &SubtitleService{stream: stream}
+}
+
+// AllowsHost reports whether subtitles may be downloaded from host
+func (s *SubtitleService) AllowsHost(host string) bool {
+	return s.stream.ProxyAllowed(host)
+}
+
+// Convert converts subtitle data from one format to another and shifts it by offset.
+// from kosong berarti format dideteksi dari isi file.
+func (s *SubtitleService) Convert(data, from, to string, offset time.Duration) (string, error) {
+	cues, err := subtitle.Parse(data, from)
+	if err != nil {
+		return "", err
+	}
+	return subtitle.Write(subtitle.Shift(cues, offset), to)
+}
+
+// ConvertURL downloads a subtitle produced by a resolver and converts it.
+// Hanya host hasil resolver yang boleh diambil, dengan header yang sama seperti proxy stream.
+func (s *SubtitleService) ConvertURL(ctx context.Context, rawURL, from, to string, offset time.Duration) (string, error) {
+	resp, err := s.stream.Proxy(ctx, rawURL, "", "")
+	if err != nil {
+		return "", err
+	}
+	if resp.Body == nil {
+		return "", fmt.Errorf("URL %s bukan file subtitle", rawURL)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSubtitleSize))
+	if err != nil {
+		return "", fmt.Errorf("gagal membaca subtitle: %v", err)
+	}
+
+	// Jika isi file tidak bisa dideteksi, pakai ekstensi dari URL
+	if from == "" {
+		if _, err := subtitle.Detect(string(data)); err != nil {
+			if parsedURL, err := url.Parse(rawURL); err == nil {
+				from = path.Ext(parsedURL.Path)
+			}
+		}
+	}
+	return s.Convert(string(data), from, to, offset)
+}
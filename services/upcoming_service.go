@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nabilulilalbab/dramaqu/models"
+)
+
+// UpcomingMaxHours is the widest window of GetUpcoming; jadwal berulang setiap minggu
+const UpcomingMaxHours = 7 * 24
+
+// UpcomingService combines the release schedule with the latest episode of every ongoing drama
+type UpcomingService struct {
+	schedule     *ScheduleService
+	animeTerbaru *AnimeTerbaruService
+}
+
+// NewUpcomingService creates a new UpcomingService
+func NewUpcomingService(schedule *ScheduleService, animeTerbaru *AnimeTerbaruService) *UpcomingService {
+	return &UpcomingService{schedule: schedule, animeTerbaru: animeTerbaru}
+}
+
+// GetUpcoming returns the episodes expected to air within the next hours, soonest first.
+// Episode berikutnya adalah episode terbaru di daftar ongoing ditambah satu; jam tayang
+// diambil dari perkiraan jadwal rilis (lihat estimateSchedule) dan dikonversi ke loc
+// (nil berarti WIB), sehingga sama di setiap request tetapi bukan jadwal resmi.
+func (s *UpcomingService) GetUpcoming(ctx context.Context, hours int, loc *time.Location) (*models.UpcomingResponse, error) {
+	// Jadwal dan daftar ongoing di-scrape bersamaan
+	var (
+		wg                      sync.WaitGroup
+		schedule                *models.ReleaseScheduleResponse
+		ongoing                 *models.OngoingDramaResponse
+		scheduleErr, ongoingErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		schedule, scheduleErr = s.schedule.GetReleaseSchedule(ctx, loc)
+	}()
+	go func() {
+		defer wg.Done()
+		ongoing, ongoingErr = s.animeTerbaru.GetAnimeTerbaru(ctx, 1)
+	}()
+	wg.Wait()
+	if scheduleErr != nil {
+		return nil, scheduleErr
+	}
+	if ongoingErr != nil {
+		return nil, ongoingErr
+	}
+
+	now := time.Now().In(scheduleLocation(loc))
+	response := &models.UpcomingResponse{
+		Source:      "dramaqu.ad",
+		Timezone:    schedule.Timezone,
+		Hours:       hours,
+		GeneratedAt: now.Format(time.RFC3339),
+		Estimated:   true,
+		Data:        upcomingEntries(schedule.Data, ongoing.Data, now, time.Duration(hours)*time.Hour),
+	}
+
+	// Confidence score: porsi entry yang nomor episode berikutnya diketahui
+	known := 0
+	for _, entry := range response.Data {
+		if entry.NextEpisode > 0 {
+			known++
+		}
+	}
+	switch {
+	case len(response.Data) == 0:
+		response.ConfidenceScore = 1.0
+		response.Message = "Tidak ada episode yang tayang dalam rentang waktu ini"
+	default:
+		response.ConfidenceScore = float64(known) / float64(len(response.Data))
+		if response.ConfidenceScore < 0.5 {
+			response.Message = "Data berhasil diambil dengan kelengkapan rendah"
+		} else if response.ConfidenceScore < 1.0 {
+			response.Message = "Data berhasil diambil dengan kelengkapan sedang"
+		} else {
+			response.Message = "Data berhasil diambil dengan kelengkapan sempurna"
+		}
+	}
+
+	return response, nil
+}
+
+// upcomingEntries returns the schedule entries airing within window after now, soonest first.
+// Episode terbaru dicocokkan lewat slug dengan daftar ongoing.
+func upcomingEntries(schedule map[string][]models.ReleaseEntry, ongoing []models.DramaEntry, now time.Time, window time.Duration) []models.UpcomingEntry {
+	latest := make(map[string]int, len(ongoing))
+	for _, drama := range ongoing {
+		latest[drama.Slug] = parseEpisodeNumber(drama.Episode)
+	}
+
+	entries := []models.UpcomingEntry{}
+	for _, day := range scheduleDays {
+		for _, item := range schedule[day] {
+			airTime, err := time.Parse(time.RFC3339, item.NextAir)
+			if err != nil {
+				continue
+			}
+			countdown := airTime.Sub(now)
+			if countdown < 0 || countdown > window {
+				continue
+			}
+
+			entry := models.UpcomingEntry{
+				Title:            strings.TrimSpace(item.Title),
+				URL:              item.URL,
+				Slug:             item.Slug,
+				CoverURL:         item.CoverURL,
+				LatestEpisode:    latest[item.Slug],
+				ReleaseTime:      item.ReleaseTime,
+				AirTime:          item.NextAir,
+				CountdownSeconds: int64(countdown / time.Second),
+			}
+			if entry.LatestEpisode > 0 {
+				entry.NextEpisode = entry.LatestEpisode + 1
+				if item.URL != "" {
+					entry.EpisodeURL = episodeURL(item.URL, entry.NextEpisode)
+				}
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CountdownSeconds < entries[j].CountdownSeconds
+	})
+	return entries
+}
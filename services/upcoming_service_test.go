@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nabilulilalbab/dramaqu/catalog"
+	"github.com/nabilulilalbab/dramaqu/models"
+)
+
+func TestUpcomingEntries(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, siteLocation)
+	schedule := map[string][]models.ReleaseEntry{
+		"Monday": {
+			{Title: "Malam Ini ", URL: "https://dramaqu.ad/malam-ini/", Slug: "malam-ini", ReleaseTime: "20:00", NextAir: "2026-10-19T20:00:00+07:00"},
+			{Title: "Sore Ini", URL: "https://dramaqu.ad/sore-ini/", Slug: "sore-ini", ReleaseTime: "13:30", NextAir: "2026-10-19T13:30:00+07:00"},
+		},
+		"Tuesday": {
+			{Title: "Besok", URL: "https://dramaqu.ad/besok/", Slug: "besok", ReleaseTime: "18:00", NextAir: "2026-10-20T18:00:00+07:00"},
+			{Title: "Tanpa Jam", Slug: "tanpa-jam", ReleaseTime: "Unknown"},
+		},
+	}
+	ongoing := []models.DramaEntry{
+		{Slug: "malam-ini", Episode: "Episode 11"},
+		{Slug: "sore-ini", Episode: "END"},
+		{Slug: "besok", Episode: "Eps 3"},
+	}
+
+	got := upcomingEntries(schedule, ongoing, now, 24*time.Hour)
+	want := []models.UpcomingEntry{
+		{Title: "Sore Ini", URL: "https://dramaqu.ad/sore-ini/", Slug: "sore-ini", ReleaseTime: "13:30", AirTime: "2026-10-19T13:30:00+07:00", CountdownSeconds: 5400},
+		{Title: "Malam Ini", URL: "https://dramaqu.ad/malam-ini/", Slug: "malam-ini", LatestEpisode: 11, NextEpisode: 12, EpisodeURL: "https://dramaqu.ad/malam-ini/12/", ReleaseTime: "20:00", AirTime: "2026-10-19T20:00:00+07:00", CountdownSeconds: 28800},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// Rentang 48 jam juga memuat episode besok
+	if got := upcomingEntries(schedule, ongoing, now, 48*time.Hour); len(got) != 3 || got[2].Slug != "besok" || got[2].NextEpisode != 4 {
+		t.Errorf("48h window = %+v", got)
+	}
+	if got := upcomingEntries(nil, nil, now, time.Hour); got == nil || len(got) != 0 {
+		t.Errorf("empty schedule = %#v, want empty slice", got)
+	}
+}
+
+func TestGetUpcomingIsStable(t *testing.T) {
+	srv := newScheduleTestServer(t, 20)
+	site := localSite(srv.URL)
+	cat := catalog.New()
+	s := NewUpcomingService(NewScheduleService(cat, site), NewAnimeTerbaruService(cat, site))
+
+	first, err := s.GetUpcoming(context.Background(), UpcomingMaxHours, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := s.GetUpcoming(context.Background(), UpcomingMaxHours, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !first.Estimated {
+		t.Error("air times must be marked as estimated")
+	}
+	if len(first.Data) != 20 || len(second.Data) != len(first.Data) {
+		t.Fatalf("got %d and %d entries, want 20", len(first.Data), len(second.Data))
+	}
+
+	// Jam tayang berasal dari perkiraan jadwal, bukan nilai acak per request
+	airTimes := make(map[string]string, len(first.Data))
+	for _, entry := range first.Data {
+		airTimes[entry.Slug] = entry.AirTime
+		if estimate := estimateSchedule(entry.Slug); entry.ReleaseTime != estimate.ReleaseTime {
+			t.Errorf("%s: release_time %s, want estimate %s", entry.Slug, entry.ReleaseTime, estimate.ReleaseTime)
+		}
+	}
+	for _, entry := range second.Data {
+		if airTimes[entry.Slug] != entry.AirTime {
+			t.Errorf("%s: air_time changed from %s to %s", entry.Slug, airTimes[entry.Slug], entry.AirTime)
+		}
+	}
+}
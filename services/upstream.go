@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// ErrNotFound is returned when the requested page does not exist on the source site,
+// baik berupa status 404 maupun halaman "tidak ditemukan" dengan status 200 (soft 404)
+var ErrNotFound = errors.New("halaman tidak ditemukan di situs sumber")
+
+// ErrUpstreamChanged is returned when a page loads but none of the expected elements are found,
+// biasanya karena struktur HTML situs sumber berubah
+var ErrUpstreamChanged = errors.New("struktur halaman sumber tidak dikenali")
+
+// UpstreamError is a failed request to the source site
+type UpstreamError struct {
+	URL string
+	// StatusCode is the HTTP status of the response, 0 if no response was received
+	StatusCode int
+	Err        error
+}
+
+func (e *UpstreamError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("gagal mengunjungi %s: status %d: %v", e.URL, e.StatusCode, e.Err)
+	}
+	return fmt.Sprintf("gagal mengunjungi %s: %v", e.URL, e.Err)
+}
+
+func (e *UpstreamError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes a 404 response match ErrNotFound
+func (e *UpstreamError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// visit visits targetURL, waits for the collector and returns an *UpstreamError
+// (dengan status HTTP jika ada) when the request fails. Pada collector async, error
+// tidak dikembalikan oleh Visit sehingga diambil dari callback OnError.
+func visit(ctx context.Context, c *colly.Collector, targetURL string) error {
+	var (
+		mu         sync.Mutex
+		mainID     uint32
+		status     int
+		requestErr error
+	)
+	// Request pertama adalah halaman target; request lain (AJAX, pagination) baru
+	// dibuat dari callback setelah halaman target diterima
+	c.OnRequest(func(r *colly.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if mainID == 0 {
+			mainID = r.ID
+		}
+	})
+	c.OnError(func(r *colly.Response, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r == nil || r.Request == nil || r.Request.ID != mainID || requestErr != nil {
+			return
+		}
+		status = r.StatusCode
+		requestErr = err
+	})
+
+	err := c.Visit(targetURL)
+	c.Wait()
+	if err == nil {
+		err = requestErr
+	}
+	if err != nil {
+		return &UpstreamError{URL: targetURL, StatusCode: status, Err: err}
+	}
+	return ctx.Err()
+}
+
+// watchNotFound detects soft 404s on a drama or episode page: halaman 404 WordPress
+// (body.error404) atau halaman pencarian/beranda yang ditampilkan, termasuk lewat
+// redirect, sebagai ganti halaman yang diminta. The returned func reports the result
+// after the visit.
+func watchNotFound(c *colly.Collector) func() bool {
+	var (
+		mu       sync.Mutex
+		notFound bool
+	)
+	c.OnHTML("body", func(e *colly.HTMLElement) {
+		if isNotFoundPage(e.Attr("class"), e.Request.URL) {
+			mu.Lock()
+			notFound = true
+			mu.Unlock()
+		}
+	})
+	return func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return notFound
+	}
+}
+
+// isNotFoundPage reports whether a page with the given body classes, served at
+// pageURL, is a "not found" page
+func isNotFoundPage(bodyClass string, pageURL *url.URL) bool {
+	for _, class := range strings.Fields(bodyClass) {
+		switch class {
+		case "error404", "search", "search-results", "search-no-results", "home":
+			return true
+		}
+	}
+	return pageURL != nil && (pageURL.Query().Has("s") || strings.Trim(pageURL.Path, "/") == "")
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/nabilulilalbab/dramaqu/catalog"
+)
+
+func TestIsNotFoundPage(t *testing.T) {
+	tests := []struct {
+		class string
+		url   string
+		want  bool
+	}{
+		{"post-template-default single single-post postid-12", "https://dramaqu.ad/judul-drama/", false},
+		{"single single-post", "https://dramaqu.ad/judul-drama/2/", false},
+		{"error404 wp-custom-logo", "https://dramaqu.ad/tidak-ada/", true},
+		{"search search-no-results", "https://dramaqu.ad/tidak-ada/", true},
+		{"single", "https://dramaqu.ad/?s=tidak+ada", true},
+		{"blog", "https://dramaqu.ad/", true},
+	}
+	for _, tt := range tests {
+		pageURL, _ := url.Parse(tt.url)
+		if got := isNotFoundPage(tt.class, pageURL); got != tt.want {
+			t.Errorf("isNotFoundPage(%q, %q) = %v, want %v", tt.class, tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestGetEpisodeBySlugNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/hilang/2/":
+			http.NotFound(w, r)
+		case "/dialihkan/2/":
+			// WordPress mengalihkan slug yang tidak dikenal ke halaman pencarian
+			http.Redirect(w, r, "/?s=dialihkan", http.StatusFound)
+		case "/":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html><body class="search search-no-results"><p>Tidak ada hasil</p></body></html>`))
+		case "/rusak/2/":
+			http.Error(w, "upstream error", http.StatusServiceUnavailable)
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html><body class="single single-post"><div class="single-content movie"><div class="title"><span>Judul</span></div></div></body></html>`))
+		}
+	}))
+	defer srv.Close()
+
+	s := NewEpisodeDetailService(catalog.New(), localSite(srv.URL))
+
+	for _, slug := range []string{"hilang", "dialihkan"} {
+		if _, err := s.GetEpisodeBySlug(context.Background(), slug, 2); !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: err = %v, want ErrNotFound", slug, err)
+		}
+	}
+
+	// Status lain dari upstream tetap error biasa dengan status HTTP-nya
+	_, err := s.GetEpisodeBySlug(context.Background(), "rusak", 2)
+	var upstream *UpstreamError
+	if !errors.As(err, &upstream) || upstream.StatusCode != http.StatusServiceUnavailable || errors.Is(err, ErrNotFound) {
+		t.Errorf("rusak: err = %v, want UpstreamError with status 503", err)
+	}
+
+	data, err := s.GetEpisodeBySlug(context.Background(), "ada", 2)
+	if err != nil {
+		t.Fatalf("existing episode: %v", err)
+	}
+	if data.Title == "" {
+		t.Error("existing episode was not parsed")
+	}
+}
+
+func TestGetDetailDramaWithoutTitle(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/artikel/":
+			// Slug yang cocok dengan post biasa: 200 tanpa konten drama
+			w.Write([]byte(`<html><body class="single single-post"><article><h1>Pengumuman</h1></article></body></html>`))
+		case "/berubah/":
+			w.Write([]byte(`<html><body class="single single-post"><div class="single-content movie"><div class="info-right"><h1>Judul</h1></div></div></body></html>`))
+		default:
+			w.Write([]byte(`<html><body class="single single-post"><div class="single-content movie"><div class="info-right"><div class="title"><span>Judul Drama</span></div></div></div></body></html>`))
+		}
+	}))
+	defer srv.Close()
+
+	s := NewDetailService(catalog.New(), localSite(srv.URL))
+
+	if _, err := s.GetDetailDrama(context.Background(), "artikel"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("artikel: err = %v, want ErrNotFound", err)
+	}
+	if _, err := s.GetDetailDrama(context.Background(), "berubah"); !errors.Is(err, ErrUpstreamChanged) {
+		t.Errorf("berubah: err = %v, want ErrUpstreamChanged", err)
+	}
+
+	data, err := s.GetDetailDrama(context.Background(), "ada")
+	if err != nil {
+		t.Fatalf("existing drama: %v", err)
+	}
+	if data.Judul != "Judul Drama" {
+		t.Errorf("Judul = %q, want %q", data.Judul, "Judul Drama")
+	}
+}
@@ -0,0 +1,91 @@
+package signer
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestSigner(bindIP bool) *Signer {
+	s := New([]byte("test-secret"), time.Hour, bindIP)
+	s.now = func() time.Time { return time.Unix(1724400000, 0) }
+	return s
+}
+
+func mustParse(t *testing.T, rawURL string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("failed to parse %q: %v", rawURL, err)
+	}
+	return u
+}
+
+func TestSignAndVerify(t *testing.T) {
+	s := newTestSigner(false)
+	signed := s.Sign("/api/v1/stream/proxy?url=https%3A%2F%2Fdrmq.stream%2Fhls%2Findex.m3u8", "1.2.3.4")
+
+	if !strings.Contains(signed, "expires=1724403600") || !strings.Contains(signed, "sig=") {
+		t.Fatalf("signed URL missing expiry or signature: %s", signed)
+	}
+	if err := s.Verify(mustParse(t, signed), "5.6.7.8"); err != nil {
+		t.Errorf("Verify without IP binding returned %v", err)
+	}
+}
+
+func TestVerifyRejectsTampering(t *testing.T) {
+	s := newTestSigner(false)
+	signed := s.Sign("/api/v1/stream/proxy?url=https%3A%2F%2Fdrmq.stream%2Fa.m3u8", "")
+
+	cases := map[string]string{
+		"changed target":  strings.Replace(signed, "a.m3u8", "b.m3u8", 1),
+		"changed path":    strings.Replace(signed, "/stream/proxy", "/stream/other", 1),
+		"extended expiry": strings.Replace(signed, "expires=1724403600", "expires=1924403600", 1),
+		"added param":     signed + "&extra=1",
+		"forged sig":      strings.Replace(signed, "sig=", "sig=AAAA", 1),
+	}
+	for name, tampered := range cases {
+		if err := s.Verify(mustParse(t, tampered), ""); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("%s: Verify = %v, want ErrInvalidSignature", name, err)
+		}
+	}
+
+	other := New([]byte("other-secret"), time.Hour, false)
+	if err := other.Verify(mustParse(t, signed), ""); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("signature from another secret: Verify = %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestVerifyRejectsExpiredAndMissing(t *testing.T) {
+	s := newTestSigner(false)
+	signed := s.Sign("/api/v1/stream/proxy?url=x", "")
+
+	s.now = func() time.Time { return time.Unix(1724403601, 0) }
+	if err := s.Verify(mustParse(t, signed), ""); !errors.Is(err, ErrExpired) {
+		t.Errorf("Verify after expiry = %v, want ErrExpired", err)
+	}
+
+	if err := s.Verify(mustParse(t, "/api/v1/stream/proxy?url=x"), ""); !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("Verify unsigned = %v, want ErrMissingSignature", err)
+	}
+}
+
+func TestVerifyBindsClientIP(t *testing.T) {
+	s := newTestSigner(true)
+	signed := s.Sign("/api/v1/stream/proxy?url=x", "10.0.0.7")
+
+	if err := s.Verify(mustParse(t, signed), "10.0.0.7"); err != nil {
+		t.Errorf("Verify from signing IP = %v", err)
+	}
+	if err := s.Verify(mustParse(t, signed), "10.0.0.8"); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify from another IP = %v, want ErrInvalidSignature", err)
+	}
+
+	// Menghapus ipb tidak boleh melepas ikatan IP
+	unbound := strings.Replace(signed, "ipb=1&", "", 1)
+	if err := s.Verify(mustParse(t, unbound), "10.0.0.8"); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify with ipb removed = %v, want ErrInvalidSignature", err)
+	}
+}
@@ -0,0 +1,122 @@
+package subtitle
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// reASSOverride matches override blocks such as {\i1} or {\pos(10,20)}
+	reASSOverride = regexp.MustCompile(`\{[^}]*\}`)
+	// reHTMLTag matches tags such as <i> or <font color="..."> used in SRT/WebVTT
+	reHTMLTag = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+)
+
+// assDefaultFormat is the event format used when a script has no Format line
+var assDefaultFormat = []string{"layer", "start", "end", "style", "name", "marginl", "marginr", "marginv", "effect", "text"}
+
+// parseASS parses the Dialogue lines of the [Events] section
+func parseASS(data string) ([]Cue, error) {
+	var cues []Cue
+	format := assDefaultFormat
+	inEvents := false
+
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			inEvents = strings.EqualFold(line, "[Events]")
+			continue
+		}
+		if !inEvents {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "Format":
+			format = nil
+			for _, field := range strings.Split(value, ",") {
+				format = append(format, strings.ToLower(strings.TrimSpace(field)))
+			}
+		case "Dialogue":
+			// Field terakhir (Text) boleh mengandung koma
+			fields := strings.SplitN(value, ",", len(format))
+			if len(fields) != len(format) {
+				continue
+			}
+			var startTS, endTS, text string
+			for i, name := range format {
+				switch name {
+				case "start":
+					startTS = fields[i]
+				case "end":
+					endTS = fields[i]
+				case "text":
+					text = fields[i]
+				}
+			}
+			start, err := parseTimestamp(startTS)
+			if err != nil {
+				return nil, err
+			}
+			end, err := parseTimestamp(endTS)
+			if err != nil {
+				return nil, err
+			}
+			if text = assToText(text); text != "" {
+				cues = append(cues, Cue{Start: start, End: end, Text: text})
+			}
+		}
+	}
+
+	// Urutan Dialogue di file ASS tidak wajib kronologis
+	sort.SliceStable(cues, func(i, j int) bool { return cues[i].Start < cues[j].Start })
+	return cues, nil
+}
+
+// assToText converts ASS dialogue text into plain multi-line text
+func assToText(text string) string {
+	text = strings.NewReplacer(`{\i1}`, "<i>", `{\i0}`, "</i>", `{\b1}`, "<b>", `{\b0}`, "</b>").Replace(text)
+	text = reASSOverride.ReplaceAllString(text, "")
+	text = strings.NewReplacer(`\N`, "\n", `\n`, "\n", `\h`, " ").Replace(text)
+	return strings.TrimSpace(text)
+}
+
+// formatASSTimestamp formats d as h:mm:ss.cc
+func formatASSTimestamp(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	cs := d.Milliseconds() / 10
+	return fmt.Sprintf("%d:%02d:%02d.%02d", cs/360000, cs/6000%60, cs/100%60, cs%100)
+}
+
+// writeASS encodes cues as a minimal ASS script with one default style
+func writeASS(cues []Cue) string {
+	var b strings.Builder
+	b.WriteString("[Script Info]\nScriptType: v4.00+\nPlayResX: 1280\nPlayResY: 720\nWrapStyle: 0\n\n")
+	b.WriteString("[V4+ Styles]\n")
+	b.WriteString("Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding\n")
+	b.WriteString("Style: Default,Arial,48,&H00FFFFFF,&H000000FF,&H00000000,&H80000000,0,0,0,0,100,100,0,0,1,2,1,2,20,20,30,1\n\n")
+	b.WriteString("[Events]\n")
+	b.WriteString("Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\n")
+
+	tags := strings.NewReplacer("<i>", `{\i1}`, "</i>", `{\i0}`, "<b>", `{\b1}`, "</b>", `{\b0}`)
+	for _, cue := range cues {
+		text := reHTMLTag.ReplaceAllStringFunc(cue.Text, func(tag string) string {
+			if converted := tags.Replace(tag); converted != tag {
+				return converted
+			}
+			return ""
+		})
+		text = strings.ReplaceAll(text, "\n", `\N`)
+		fmt.Fprintf(&b, "Dialogue: 0,%s,%s,Default,,0,0,0,,%s\n", formatASSTimestamp(cue.Start), formatASSTimestamp(cue.End), text)
+	}
+	return b.String()
+}
@@ -0,0 +1,145 @@
+// Package subtitle mem-parsing dan mengonversi subtitle antara format
+// SubRip (SRT), WebVTT dan Advanced SubStation Alpha (ASS/SSA).
+package subtitle
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Supported formats
+const (
+	FormatSRT = "srt"
+	FormatVTT = "vtt"
+	FormatASS = "ass"
+)
+
+// ErrUnknownFormat is returned when a subtitle format is not supported or cannot be detected
+var ErrUnknownFormat = errors.New("subtitle: format tidak dikenali")
+
+// ErrNoCues is returned when a subtitle file contains no cues
+var ErrNoCues = errors.New("subtitle: tidak ada cue yang bisa dibaca")
+
+// Cue is one timed subtitle entry. Baris teks dipisah dengan "\n".
+type Cue struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+// NormalizeFormat maps format names and extensions to a supported format
+func NormalizeFormat(format string) (string, error) {
+	switch strings.ToLower(strings.TrimPrefix(strings.TrimSpace(format), ".")) {
+	case "srt", "subrip":
+		return FormatSRT, nil
+	case "vtt", "webvtt":
+		return FormatVTT, nil
+	case "ass", "ssa":
+		return FormatASS, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+}
+
+// Detect guesses the format of subtitle data from its content
+func Detect(data string) (string, error) {
+	data = normalizeNewlines(data)
+	trimmed := strings.TrimSpace(data)
+	switch {
+	case strings.HasPrefix(trimmed, "WEBVTT"):
+		return FormatVTT, nil
+	case strings.HasPrefix(trimmed, "[Script Info]") || strings.Contains(data, "\n[Events]"):
+		return FormatASS, nil
+	case strings.Contains(data, "-->"):
+		return FormatSRT, nil
+	}
+	return "", ErrUnknownFormat
+}
+
+// Parse reads subtitle data of the given format. Format kosong berarti dideteksi otomatis.
+func Parse(data, format string) ([]Cue, error) {
+	var err error
+	if format == "" {
+		format, err = Detect(data)
+	} else {
+		format, err = NormalizeFormat(format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data = normalizeNewlines(data)
+	var cues []Cue
+	switch format {
+	case FormatSRT, FormatVTT:
+		cues = parseTimedBlocks(data)
+	case FormatASS:
+		cues, err = parseASS(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(cues) == 0 {
+		return nil, ErrNoCues
+	}
+	return cues, nil
+}
+
+// Write encodes cues in the given format
+func Write(cues []Cue, format string) (string, error) {
+	format, err := NormalizeFormat(format)
+	if err != nil {
+		return "", err
+	}
+	switch format {
+	case FormatSRT:
+		return writeSRT(cues), nil
+	case FormatVTT:
+		return writeVTT(cues), nil
+	default:
+		return writeASS(cues), nil
+	}
+}
+
+// Shift moves every cue by offset. Cue yang berakhir sebelum 0 dibuang,
+// dan cue yang terpotong dimulai dari 0.
+func Shift(cues []Cue, offset time.Duration) []Cue {
+	if offset == 0 {
+		return cues
+	}
+	shifted := make([]Cue, 0, len(cues))
+	for _, cue := range cues {
+		cue.Start += offset
+		cue.End += offset
+		if cue.End <= 0 {
+			continue
+		}
+		if cue.Start < 0 {
+			cue.Start = 0
+		}
+		shifted = append(shifted, cue)
+	}
+	return shifted
+}
+
+// ContentType returns the HTTP content type of a format
+func ContentType(format string) string {
+	switch format {
+	case FormatVTT:
+		return "text/vtt; charset=utf-8"
+	case FormatSRT:
+		return "application/x-subrip; charset=utf-8"
+	case FormatASS:
+		return "text/x-ssa; charset=utf-8"
+	}
+	return "text/plain; charset=utf-8"
+}
+
+// normalizeNewlines strips a UTF-8 BOM and converts CRLF/CR line endings to LF
+func normalizeNewlines(data string) string {
+	data = strings.TrimPrefix(data, "\ufeff")
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	return strings.ReplaceAll(data, "\r", "\n")
+}
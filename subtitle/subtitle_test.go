@@ -0,0 +1,129 @@
+package subtitle
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func loadFixture(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("failed to read fixture %s: %v", name, err)
+	}
+	return string(data)
+}
+
+var wantCues = []Cue{
+	{Start: 1500 * time.Millisecond, End: 4 * time.Second, Text: "Annyeong, <i>oppa</i>!"},
+	{Start: 5 * time.Second, End: 7250 * time.Millisecond, Text: "Kamu dari mana saja?\nAku menunggumu."},
+	{Start: 62 * time.Second, End: 63 * time.Second, Text: "Sampai jumpa besok."},
+}
+
+func TestParseFixtures(t *testing.T) {
+	for _, name := range []string{"sample.srt", "sample.vtt", "sample.ass"} {
+		t.Run(name, func(t *testing.T) {
+			cues, err := Parse(loadFixture(t, name), "")
+			if err != nil {
+				t.Fatalf("Parse returned error: %v", err)
+			}
+			if !reflect.DeepEqual(cues, wantCues) {
+				t.Errorf("cues = %+v, want %+v", cues, wantCues)
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	cases := map[string]string{
+		"sample.srt": FormatSRT,
+		"sample.vtt": FormatVTT,
+		"sample.ass": FormatASS,
+	}
+	for name, want := range cases {
+		if got, err := Detect(loadFixture(t, name)); err != nil || got != want {
+			t.Errorf("Detect(%s) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := Detect("bukan subtitle"); err == nil {
+		t.Error("Detect must fail for plain text")
+	}
+}
+
+func TestWriteVTT(t *testing.T) {
+	got, err := Write(wantCues[:2], FormatVTT)
+	if err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	want := "WEBVTT\n\n" +
+		"00:00:01.500 --> 00:00:04.000\nAnnyeong, <i>oppa</i>!\n\n" +
+		"00:00:05.000 --> 00:00:07.250\nKamu dari mana saja?\nAku menunggumu.\n\n"
+	if got != want {
+		t.Errorf("Write VTT =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestWriteSRT(t *testing.T) {
+	got, _ := Write(wantCues[2:], FormatSRT)
+	want := "1\n00:01:02,000 --> 00:01:03,000\nSampai jumpa besok.\n\n"
+	if got != want {
+		t.Errorf("Write SRT = %q, want %q", got, want)
+	}
+}
+
+func TestWriteASSConvertsTags(t *testing.T) {
+	got, _ := Write(wantCues[:2], FormatASS)
+	for _, want := range []string{
+		"[Events]\n",
+		`Dialogue: 0,0:00:01.50,0:00:04.00,Default,,0,0,0,,Annyeong, {\i1}oppa{\i0}!`,
+		`Dialogue: 0,0:00:05.00,0:00:07.25,Default,,0,0,0,,Kamu dari mana saja?\NAku menunggumu.`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ASS output missing %q\n%s", want, got)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []string{FormatSRT, FormatVTT, FormatASS} {
+		out, err := Write(wantCues, format)
+		if err != nil {
+			t.Fatalf("Write %s returned error: %v", format, err)
+		}
+		cues, err := Parse(out, format)
+		if err != nil {
+			t.Fatalf("Parse %s returned error: %v", format, err)
+		}
+		if !reflect.DeepEqual(cues, wantCues) {
+			t.Errorf("%s round trip = %+v, want %+v", format, cues, wantCues)
+		}
+	}
+}
+
+func TestShift(t *testing.T) {
+	shifted := Shift(wantCues, -4500*time.Millisecond)
+	want := []Cue{
+		{Start: 500 * time.Millisecond, End: 2750 * time.Millisecond, Text: wantCues[1].Text},
+		{Start: 57500 * time.Millisecond, End: 58500 * time.Millisecond, Text: wantCues[2].Text},
+	}
+	if !reflect.DeepEqual(shifted, want) {
+		t.Errorf("Shift(-4.5s) = %+v, want %+v", shifted, want)
+	}
+
+	clamped := Shift(wantCues[:1], -2*time.Second)
+	if len(clamped) != 1 || clamped[0].Start != 0 || clamped[0].End != 2*time.Second {
+		t.Errorf("cue crossing zero must be clamped, got %+v", clamped)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	if _, err := Parse("WEBVTT\n\nNOTE kosong\n", ""); err != ErrNoCues {
+		t.Errorf("Parse empty VTT = %v, want ErrNoCues", err)
+	}
+	if _, err := Parse("1\n00:00:01,000 --> 00:00:02,000\nhai\n", "txt"); err == nil {
+		t.Error("Parse must reject unknown formats")
+	}
+}
@@ -0,0 +1,100 @@
+package subtitle
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// reTiming matches SRT and WebVTT cue timings, e.g. "00:00:01,000 --> 00:00:04,500" or "01:02.500 --> 01:04.000"
+var reTiming = regexp.MustCompile(`^\s*((?:\d+:)?\d{1,2}:\d{2}[.,]\d{1,3})\s*-->\s*((?:\d+:)?\d{1,2}:\d{2}[.,]\d{1,3})`)
+
+// parseTimedBlocks parses SRT and WebVTT cue blocks. Header WEBVTT serta blok
+// NOTE/STYLE/REGION tidak memiliki timing sehingga otomatis dilewati.
+func parseTimedBlocks(data string) []Cue {
+	var cues []Cue
+	for _, block := range strings.Split(data, "\n\n") {
+		lines := strings.Split(strings.Trim(block, "\n"), "\n")
+		for i, line := range lines {
+			m := reTiming.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			start, errStart := parseTimestamp(m[1])
+			end, errEnd := parseTimestamp(m[2])
+			if errStart != nil || errEnd != nil || end < start {
+				break
+			}
+			text := strings.TrimSpace(strings.Join(lines[i+1:], "\n"))
+			if text != "" {
+				cues = append(cues, Cue{Start: start, End: end, Text: text})
+			}
+			break
+		}
+	}
+	return cues
+}
+
+// parseTimestamp parses "hh:mm:ss,mmm", "mm:ss.mmm" and ASS "h:mm:ss.cc" timestamps
+func parseTimestamp(ts string) (time.Duration, error) {
+	ts = strings.Replace(strings.TrimSpace(ts), ",", ".", 1)
+	clock, frac, _ := strings.Cut(ts, ".")
+
+	parts := strings.Split(clock, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("timestamp tidak valid: %q", ts)
+	}
+	var total time.Duration
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("timestamp tidak valid: %q", ts)
+		}
+		total = total*60 + time.Duration(n)*time.Second
+	}
+
+	if frac != "" {
+		// Pecahan detik bisa 1-3 digit (ASS memakai centisecond)
+		for len(frac) < 3 {
+			frac += "0"
+		}
+		ms, err := strconv.Atoi(frac[:3])
+		if err != nil {
+			return 0, fmt.Errorf("timestamp tidak valid: %q", ts)
+		}
+		total += time.Duration(ms) * time.Millisecond
+	}
+	return total, nil
+}
+
+// formatTimestamp formats d as hh:mm:ss<sep>mmm
+func formatTimestamp(d time.Duration, sep string) string {
+	if d < 0 {
+		d = 0
+	}
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
+
+// writeSRT encodes cues as SubRip
+func writeSRT(cues []Cue) string {
+	var b strings.Builder
+	for i, cue := range cues {
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n\n", i+1, formatTimestamp(cue.Start, ","), formatTimestamp(cue.End, ","), cue.Text)
+	}
+	return b.String()
+}
+
+// writeVTT encodes cues as WebVTT
+func writeVTT(cues []Cue) string {
+	var b strings.Builder
+	b.WriteString("WEBVTT\n\n")
+	for _, cue := range cues {
+		// "-->" tidak boleh muncul di teks cue WebVTT
+		text := strings.ReplaceAll(cue.Text, "-->", "->")
+		fmt.Fprintf(&b, "%s --> %s\n%s\n\n", formatTimestamp(cue.Start, "."), formatTimestamp(cue.End, "."), text)
+	}
+	return b.String()
+}
@@ -0,0 +1,50 @@
+package urlguard
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDialControl(t *testing.T) {
+	if err := DialControl("tcp", "104.21.32.1:443", nil); err != nil {
+		t.Errorf("public address rejected: %v", err)
+	}
+	for _, address := range []string{"127.0.0.1:80", "[::1]:443", "169.254.169.254:80", "10.0.0.2:8080", "dramaqu.ad:443", "garbage"} {
+		if err := DialControl("tcp", address, nil); !errors.Is(err, ErrInvalidURL) {
+			t.Errorf("DialControl(%q) = %v, want ErrInvalidURL", address, err)
+		}
+	}
+}
+
+func TestCheckRedirect(t *testing.T) {
+	via := []*http.Request{httptest.NewRequest(http.MethodGet, "https://dramaqu.ad/", nil)}
+	for _, target := range []string{"http://127.0.0.1/", "http://[::1]/admin", "http://169.254.169.254/latest/meta-data/", "ftp://104.21.32.1/"} {
+		if err := CheckRedirect(httptest.NewRequest(http.MethodGet, target, nil), via); err == nil {
+			t.Errorf("redirect to %s accepted", target)
+		}
+	}
+	if err := CheckRedirect(httptest.NewRequest(http.MethodGet, "https://104.21.32.1/", nil), via); err != nil {
+		t.Errorf("redirect to public IP rejected: %v", err)
+	}
+	if err := CheckRedirect(httptest.NewRequest(http.MethodGet, "https://104.21.32.1/", nil), make([]*http.Request, maxRedirects)); err == nil {
+		t.Error("redirect chain must be bounded")
+	}
+}
+
+func TestNewClientRefusesLoopback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached the loopback server")
+	}))
+	defer srv.Close()
+
+	resp, err := NewClient(0).Get(srv.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("client connected to a loopback address")
+	}
+	if !errors.Is(err, ErrInvalidURL) {
+		t.Errorf("error %v must wrap ErrInvalidURL", err)
+	}
+}
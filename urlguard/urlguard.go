@@ -0,0 +1,190 @@
+// Package urlguard validates user-supplied URLs before the API fetches them,
+// so that no endpoint can be turned into an open fetcher (SSRF).
+package urlguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// maxURLLength rejects absurdly long URLs before parsing
+const maxURLLength = 2048
+
+// ErrInvalidURL is wrapped by every validation error
+var ErrInvalidURL = errors.New("URL tidak valid")
+
+// reHostname matches a plain ASCII hostname (no IP literals, no percent-encoding)
+var reHostname = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)+$`)
+
+// blockedPrefixes are non-public ranges not covered by the netip helpers
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // TEST-NET-1
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // TEST-NET-2
+	netip.MustParsePrefix("203.0.113.0/24"),  // TEST-NET-3
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved
+	netip.MustParsePrefix("64:ff9b::/96"),    // NAT64, bisa memetakan ke IPv4 privat
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+	netip.MustParsePrefix("2002::/16"),       // 6to4, menyisipkan alamat IPv4
+}
+
+// LookupFunc resolves a hostname to its IP addresses
+type LookupFunc func(ctx context.Context, host string) ([]net.IP, error)
+
+// Policy describes the URLs one endpoint accepts
+type Policy struct {
+	// AllowHost reports whether a normalized hostname is on the allowlist
+	AllowHost func(host string) bool
+	// Path, if set, must match the escaped path of the URL
+	Path *regexp.Regexp
+}
+
+// Validator checks URLs against a Policy and resolves their host
+type Validator struct {
+	lookup LookupFunc
+}
+
+// New creates a Validator. lookup nil memakai DNS resolver bawaan sistem.
+func New(lookup LookupFunc) *Validator {
+	if lookup == nil {
+		lookup = func(ctx context.Context, host string) ([]net.IP, error) {
+			return net.DefaultResolver.LookupIP(ctx, "ip", host)
+		}
+	}
+	return &Validator{lookup: lookup}
+}
+
+// HostList returns an AllowHost func matching exactly the given hostnames
+func HostList(hosts ...string) func(string) bool {
+	allowed := make(map[string]bool, len(hosts))
+	for _, host := range hosts {
+		if host = NormalizeHost(host); host != "" {
+			allowed[host] = true
+		}
+	}
+	return func(host string) bool {
+		return allowed[NormalizeHost(host)]
+	}
+}
+
+// NormalizeHost lowercases a hostname and strips the trailing root dot
+func NormalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
+
+// Validate parses rawURL and checks scheme, credentials, port, host allowlist,
+// path shape and the resolved IP addresses. URL yang dikembalikan sudah
+// dinormalisasi dan aman untuk di-fetch.
+func (v *Validator) Validate(ctx context.Context, rawURL string, policy Policy) (*url.URL, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" || len(rawURL) > maxURLLength {
+		return nil, invalid("URL kosong atau terlalu panjang")
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, invalid("URL tidak dapat di-parse")
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, invalid("scheme harus http atau https")
+	}
+	if u.Opaque != "" || u.Host == "" {
+		return nil, invalid("URL harus absolut dengan host")
+	}
+	if u.User != nil {
+		return nil, invalid("URL tidak boleh berisi kredensial")
+	}
+
+	switch port := u.Port(); {
+	case port == "":
+	case u.Scheme == "http" && port == "80", u.Scheme == "https" && port == "443":
+	default:
+		return nil, invalid(fmt.Sprintf("port %s tidak diizinkan", port))
+	}
+
+	host := NormalizeHost(u.Hostname())
+	if net.ParseIP(host) != nil || !reHostname.MatchString(host) {
+		return nil, invalid("host harus berupa nama domain")
+	}
+	if policy.AllowHost == nil || !policy.AllowHost(host) {
+		return nil, invalid(fmt.Sprintf("host %s tidak ada di allowlist", host))
+	}
+
+	escapedPath := u.EscapedPath()
+	if hasDotSegment(u.Path) || strings.Contains(strings.ToLower(escapedPath), "%2f") {
+		return nil, invalid("path tidak valid")
+	}
+	if policy.Path != nil && !policy.Path.MatchString(escapedPath) {
+		return nil, invalid("bentuk path tidak sesuai")
+	}
+
+	if err := v.checkResolved(ctx, host); err != nil {
+		return nil, err
+	}
+
+	// Normalisasi: host tanpa port default, tanpa fragment
+	u.Host = host
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u, nil
+}
+
+// checkResolved rejects hosts that resolve to any non-public address
+func (v *Validator) checkResolved(ctx context.Context, host string) error {
+	ips, err := v.lookup(ctx, host)
+	if err != nil {
+		return invalid(fmt.Sprintf("host %s tidak dapat di-resolve", host))
+	}
+	if len(ips) == 0 {
+		return invalid(fmt.Sprintf("host %s tidak memiliki alamat IP", host))
+	}
+	for _, ip := range ips {
+		if !IsPublicIP(ip) {
+			return invalid(fmt.Sprintf("host %s mengarah ke alamat IP non-publik", host))
+		}
+	}
+	return nil
+}
+
+// IsPublicIP reports whether ip is a globally routable unicast address
+func IsPublicIP(ip net.IP) bool {
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return false
+	}
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasDotSegment reports whether a decoded path contains "." or ".." segments
+func hasDotSegment(p string) bool {
+	for _, segment := range strings.Split(p, "/") {
+		if segment == "." || segment == ".." {
+			return true
+		}
+	}
+	return false
+}
+
+func invalid(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidURL, reason)
+}